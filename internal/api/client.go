@@ -3,6 +3,8 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -19,18 +21,35 @@ const (
 // Client is the Realtime Register API client.
 type Client struct {
 	httpClient *http.Client
+	retry      *RetryTransport
 	apiKey     string
 	baseURL    string
 	userAgent  string
+	cache      Cache
 }
 
+// Cache stores read-only API responses between runs. Implementations should
+// treat failures as misses; the client never fails a request because of them.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte, ttl time.Duration) error
+}
+
+// Cache lifetimes for slowly changing data.
+const (
+	PricelistTTL = time.Hour
+	TLDTTL       = 24 * time.Hour
+)
+
 // NewClient creates a Client with retry transport and auth.
 func NewClient(apiKey string) *Client {
+	retry := NewRetryTransport(http.DefaultTransport)
 	return &Client{
 		httpClient: &http.Client{
-			Transport: NewRetryTransport(http.DefaultTransport),
+			Transport: retry,
 			Timeout:   defaultTimeout,
 		},
+		retry:     retry,
 		apiKey:    apiKey,
 		baseURL:   ProductionURL,
 		userAgent: "rr/dev",
@@ -47,6 +66,55 @@ func (c *Client) SetUserAgent(ua string) {
 	c.userAgent = ua
 }
 
+// SetTimeout overrides the timeout for each request, including retries.
+func (c *Client) SetTimeout(d time.Duration) {
+	c.httpClient.Timeout = d
+}
+
+// SetMaxRetries overrides how often failed requests are retried.
+func (c *Client) SetMaxRetries(n int) {
+	c.retry.MaxRetries = n
+}
+
+// SetCache enables caching of pricelists and TLD metadata. nil disables it.
+func (c *Client) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// Refreshed returns a client with the same settings and connections that
+// ignores cached responses but still stores fresh ones.
+func (c *Client) Refreshed() *Client {
+	refreshed := *c
+	if c.cache != nil {
+		refreshed.cache = refreshCache{c.cache}
+	}
+	return &refreshed
+}
+
+// refreshCache ignores cached entries but stores fresh responses.
+type refreshCache struct{ Cache }
+
+func (refreshCache) Get(string) ([]byte, bool) { return nil, false }
+
+// SetRateLimit limits the client to rps requests per second across all
+// goroutines using it. 0 removes the limit.
+func (c *Client) SetRateLimit(rps float64) {
+	c.retry.Limiter = NewRateLimiter(rps)
+}
+
+// SetRetryBackoff overrides the delay before the first retry.
+func (c *Client) SetRetryBackoff(d time.Duration) {
+	c.retry.Backoff = d
+}
+
+// EnableLogging logs every request attempt and retry to out and, if har is
+// set, records them in har. Either may be nil.
+func (c *Client) EnableLogging(out io.Writer, har *HARLog) {
+	lt := NewLoggingTransport(c.retry.Base, out, har)
+	c.retry.Base = lt
+	c.retry.OnRetry = lt.LogRetry
+}
+
 // do executes an API request with auth and error handling.
 func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
 	var bodyReader io.Reader
@@ -58,6 +126,9 @@ func (c *Client) do(ctx context.Context, method, path string, body, out any) err
 		bodyReader = bytes.NewReader(data)
 	}
 
+	var attempts int
+	ctx = withAttemptCounter(ctx, &attempts)
+
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
@@ -75,6 +146,9 @@ func (c *Client) do(ctx context.Context, method, path string, body, out any) err
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if attempts > 1 {
+			return fmt.Errorf("execute request (after %d attempts): %w", attempts, err)
+		}
 		return fmt.Errorf("execute request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
@@ -85,7 +159,7 @@ func (c *Client) do(ctx context.Context, method, path string, body, out any) err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return NewAPIError(resp.StatusCode, respBody)
+		return annotate(NewAPIError(resp.StatusCode, respBody), attempts, resp.Header.Get("X-Request-Id"))
 	}
 
 	if out != nil && len(respBody) > 0 {
@@ -97,6 +171,34 @@ func (c *Client) do(ctx context.Context, method, path string, body, out any) err
 	return nil
 }
 
+// getCached performs a GET request, answering from the cache if a response
+// younger than ttl is stored for this endpoint and account.
+func (c *Client) getCached(ctx context.Context, path string, ttl time.Duration, out any) error {
+	if c.cache == nil {
+		return c.Get(ctx, path, out)
+	}
+
+	key := c.cacheKey(path)
+	if data, ok := c.cache.Get(key); ok && json.Unmarshal(data, out) == nil {
+		return nil
+	}
+
+	if err := c.Get(ctx, path, out); err != nil {
+		return err
+	}
+	if data, err := json.Marshal(out); err == nil {
+		_ = c.cache.Set(key, data, ttl)
+	}
+	return nil
+}
+
+// cacheKey identifies a response by endpoint, account and path. The API key
+// is hashed so it is never written to disk.
+func (c *Client) cacheKey(path string) string {
+	sum := sha256.Sum256([]byte(c.apiKey))
+	return c.baseURL + " " + hex.EncodeToString(sum[:8]) + " " + path
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, path string, out any) error {
 	return c.do(ctx, http.MethodGet, path, nil, out)
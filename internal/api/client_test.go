@@ -1,9 +1,20 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestMockServer_GetZone(t *testing.T) {
@@ -107,3 +118,681 @@ func TestMockServer_APIError(t *testing.T) {
 		t.Errorf("APIError.StatusCode = %d, want 400", apiErr.StatusCode)
 	}
 }
+
+func TestMockServer_CreateHost(t *testing.T) {
+	mock := NewMockServer(t)
+	defer mock.Close()
+
+	var got HostRequest
+	mock.On("POST", "/hosts/ns1.example.com", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	addrs, err := NewHostAddresses("192.0.2.53", "2001:db8::53")
+	if err != nil {
+		t.Fatalf("NewHostAddresses() error = %v", err)
+	}
+
+	client := mock.Client()
+	if err := client.CreateHost(context.Background(), "ns1.example.com", &HostRequest{Addresses: addrs}); err != nil {
+		t.Fatalf("CreateHost() error = %v", err)
+	}
+
+	if len(got.Addresses) != 2 {
+		t.Fatalf("request addresses = %d, want 2", len(got.Addresses))
+	}
+	if got.Addresses[0].IPVersion != IPv4 || got.Addresses[1].IPVersion != IPv6 {
+		t.Errorf("request addresses = %+v, want V4 then V6", got.Addresses)
+	}
+
+	if _, err := NewHostAddresses("ns1.example.com"); err == nil {
+		t.Error("NewHostAddresses(hostname): expected error")
+	}
+}
+
+func TestMockServer_ListAllDomains(t *testing.T) {
+	mock := NewMockServer(t)
+	defer mock.Close()
+
+	mock.On("GET", "/domains", func(w http.ResponseWriter, r *http.Request) {
+		resp := ListResponse[Domain]{Pagination: Pagination{Limit: pageSize, Total: pageSize + 1}}
+		if r.URL.Query().Get("offset") == "" {
+			resp.Entities = make([]Domain, pageSize)
+		} else {
+			resp.Entities = []Domain{{DomainName: "last.com"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	domains, err := mock.Client().ListAllDomains(context.Background(), DomainListOptions{})
+	if err != nil {
+		t.Fatalf("ListAllDomains() error = %v", err)
+	}
+	if len(domains) != pageSize+1 {
+		t.Fatalf("len(domains) = %d, want %d", len(domains), pageSize+1)
+	}
+	if domains[pageSize].DomainName != "last.com" {
+		t.Errorf("last domain = %q, want last.com", domains[pageSize].DomainName)
+	}
+}
+
+func TestContactRequest_Validate(t *testing.T) {
+	valid := ContactRequest{
+		Name:       "Jane Doe",
+		Email:      "jane@example.com",
+		Phone:      "+31.201234567",
+		Address:    []string{"Main Street 1"},
+		City:       "Amsterdam",
+		PostalCode: "1000AA",
+		Country:    "NL",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *ContactRequest)
+		field  string
+	}{
+		{"missing name", func(r *ContactRequest) { r.Name = "" }, "name"},
+		{"bad email", func(r *ContactRequest) { r.Email = "Jane <jane@example.com>" }, "email"},
+		{"phone without plus", func(r *ContactRequest) { r.Phone = "0201234567" }, "phone"},
+		{"phone too long", func(r *ContactRequest) { r.Phone = "+31.2012345678901234" }, "phone"},
+		{"bad fax", func(r *ContactRequest) { r.Fax = "fax" }, "fax"},
+		{"no address", func(r *ContactRequest) { r.Address = nil }, "address"},
+		{"unknown country", func(r *ContactRequest) { r.Country = "XX" }, "country"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			err := r.Validate()
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if verr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", verr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidateContactProperties(t *testing.T) {
+	defs := []ContactProperty{
+		{Name: "language", Mandatory: true, Values: map[string]string{"en": "English", "nl": "Dutch"}},
+		{Name: "vatNumber"},
+	}
+
+	if err := ValidateContactProperties(defs, map[string]string{"language": "nl"}); err != nil {
+		t.Fatalf("ValidateContactProperties() error = %v", err)
+	}
+
+	err := ValidateContactProperties(defs, map[string]string{"language": "fr", "entityType": "1"})
+	if err == nil {
+		t.Fatal("ValidateContactProperties() expected error")
+	}
+	for _, want := range []string{"language must be one of en, nl", "entityType is not a property"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	err = ValidateContactProperties(defs, nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Field != "language" {
+		t.Errorf("ValidateContactProperties(nil) = %v, want required language", err)
+	}
+}
+
+func TestPricelist_ByTLD(t *testing.T) {
+	p := &Pricelist{Prices: []PricelistEntry{
+		{Product: "domain_nl", Action: "RENEW", Currency: "EUR", Price: 800},
+		{Product: "domain_com", Action: "CREATE", Currency: "USD", Price: 1100},
+		{Product: "domain_com", Action: "CREATE", Currency: "EUR", Price: 1000},
+		{Product: "domain_com", Action: "RENEW", Currency: "EUR", Price: 1200},
+		{Product: "ssl_dv", Action: "CREATE", Currency: "EUR", Price: 5000},
+	}}
+
+	got := p.ByTLD()
+	want := []TLDPrice{
+		{TLD: "com", Currency: "EUR", Create: 1000, Renew: 1200, Transfer: -1, Restore: -1},
+		{TLD: "com", Currency: "USD", Create: 1100, Renew: -1, Transfer: -1, Restore: -1},
+		{TLD: "nl", Currency: "EUR", Create: -1, Renew: 800, Transfer: -1, Restore: -1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ByTLD() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ByTLD()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if price, cur, ok := p.Price(".NL", ActionRenew); !ok || price != 800 || cur != "EUR" {
+		t.Errorf("Price(.NL, RENEW) = %d %s %v", price, cur, ok)
+	}
+	if _, _, ok := p.Price("nl", ActionCreate); ok {
+		t.Error("Price(nl, CREATE) found, want missing")
+	}
+}
+
+func TestClient_EnableLogging(t *testing.T) {
+	mock := NewMockServer(t)
+	defer mock.Close()
+
+	mock.OnJSON("POST", "/domains/example.com/transfer", 200, map[string]string{"status": "ok"})
+
+	var buf bytes.Buffer
+	har := NewHARLog("rr", "test")
+	client := mock.Client()
+	client.EnableLogging(&buf, har)
+
+	body := map[string]string{"authCode": "s3cret", "registrant": "jdoe"}
+	if err := client.Post(context.Background(), "/domains/example.com/transfer", body, nil); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	log := buf.String()
+	for _, secret := range []string{"test-api-key", "s3cret"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("log contains secret %q:\n%s", secret, log)
+		}
+	}
+	for _, want := range []string{"> POST " + mock.URL + "/domains/example.com/transfer", "Authorization: ApiKey REDACTED", `"registrant":"jdoe"`, "< 200 OK"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log does not contain %q:\n%s", want, log)
+		}
+	}
+
+	entries := har.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("HAR entries = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Response.Status != 200 || e.Request.Method != "POST" {
+		t.Errorf("HAR entry = %s %d", e.Request.Method, e.Response.Status)
+	}
+	if e.Request.PostData == nil || strings.Contains(e.Request.PostData.Text, "s3cret") {
+		t.Errorf("HAR postData not redacted: %+v", e.Request.PostData)
+	}
+
+	var out bytes.Buffer
+	if _, err := har.WriteTo(&out); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if strings.Contains(out.String(), "test-api-key") {
+		t.Error("HAR output contains the API key")
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		wantAttempts int32
+	}{
+		{"GET retried on 503", http.MethodGet, 503, 3},
+		{"POST not retried on 503", http.MethodPost, 503, 1},
+		{"DELETE not retried on 500", http.MethodDelete, 500, 1},
+		{"POST retried on 429", http.MethodPost, 429, 3},
+		{"GET not retried on 400", http.MethodGet, 400, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMockServer(t)
+			defer mock.Close()
+
+			var calls atomic.Int32
+			mock.On(tt.method, "/domains/example.com", func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != `{"period":1}` {
+					t.Errorf("request body = %q", body)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"error":{"message":"try again"}}`))
+			})
+
+			client := mock.Client()
+			client.SetMaxRetries(2)
+			client.SetRetryBackoff(time.Millisecond)
+
+			var err error
+			if tt.method == http.MethodPost {
+				err = client.Post(context.Background(), "/domains/example.com", map[string]int{"period": 1}, nil)
+			} else {
+				err = client.do(context.Background(), tt.method, "/domains/example.com", nil, nil)
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if got := calls.Load(); got != tt.wantAttempts {
+				t.Errorf("server saw %d requests, want %d", got, tt.wantAttempts)
+			}
+
+			var apiErr *APIError
+			var rateErr *RateLimitError
+			attempts := 0
+			switch {
+			case errors.As(err, &rateErr):
+				attempts = rateErr.Attempts
+			case errors.As(err, &apiErr):
+				attempts = apiErr.Attempts
+			default:
+				t.Fatalf("expected API error, got %T", err)
+			}
+			if attempts != int(tt.wantAttempts) {
+				t.Errorf("error attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantAttempts > 1 && !strings.Contains(err.Error(), "after 3 attempts") {
+				t.Errorf("error %q does not mention attempts", err)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	rt := &RetryTransport{Backoff: 100 * time.Millisecond}
+	for attempt, want := range []time.Duration{100, 200, 400} {
+		want *= time.Millisecond
+		for range 20 {
+			if got := rt.backoff(attempt); got < want/2 || got > want {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", attempt, got, want/2, want)
+			}
+		}
+	}
+	if got := rt.backoff(20); got > maxBackoff {
+		t.Errorf("backoff(20) = %s, want at most %s", got, maxBackoff)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := NewRateLimiter(2)
+	l.now = func() time.Time { return now }
+
+	// A full bucket allows a burst of two, then one request per 500ms.
+	for i := range 2 {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("reserve() #%d = %s, want 0", i, d)
+		}
+	}
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Errorf("reserve() on empty bucket = %s, want 500ms", d)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("reserve() after refill = %s, want 0", d)
+	}
+
+	// An exhausted limit pauses until the reset.
+	l.Update(http.StatusOK, http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"10"}})
+	if d := l.reserve(); d != 10*time.Second {
+		t.Errorf("reserve() after exhausted limit = %s, want 10s", d)
+	}
+
+	// Retry-After on 429 extends the pause, even without a configured rate.
+	u := NewRateLimiter(0)
+	u.now = func() time.Time { return now }
+	if d := u.reserve(); d != 0 {
+		t.Errorf("unlimited reserve() = %s, want 0", d)
+	}
+	u.Update(http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}})
+	if d := u.reserve(); d != 3*time.Second {
+		t.Errorf("reserve() after Retry-After = %s, want 3s", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := u.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() on cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+// mapCache is an in-memory Cache for tests.
+type mapCache map[string][]byte
+
+func (m mapCache) Get(key string) ([]byte, bool) {
+	data, ok := m[key]
+	return data, ok
+}
+
+func (m mapCache) Set(key string, data []byte, _ time.Duration) error {
+	m[key] = data
+	return nil
+}
+
+func TestClient_Cache(t *testing.T) {
+	mock := NewMockServer(t)
+	defer mock.Close()
+
+	var calls atomic.Int32
+	mock.On("GET", "/tlds/com", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tld":"com","minPeriod":1}`))
+	})
+
+	cache := mapCache{}
+	client := mock.Client()
+	client.SetCache(cache)
+
+	for range 2 {
+		info, err := client.GetTLD(context.Background(), "com")
+		if err != nil {
+			t.Fatalf("GetTLD() error = %v", err)
+		}
+		if info.TLD != "com" || info.MinPeriod != 1 {
+			t.Errorf("GetTLD() = %+v", info)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+	for key := range cache {
+		if strings.Contains(key, "test-api-key") {
+			t.Errorf("cache key %q contains the API key", key)
+		}
+	}
+
+	// Another account must not see this account's entries.
+	other := NewClient("other-key")
+	other.SetBaseURL(mock.URL)
+	other.SetCache(cache)
+	if _, err := other.GetTLD(context.Background(), "com"); err != nil {
+		t.Fatalf("GetTLD() error = %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	flat := []byte(`{"type":"ValidationError","message":"Validation of the request failed",
+		"violations":[{"field":"email","message":"is not a valid email address"}],"requestId":"req-1"}`)
+
+	err := NewAPIError(400, flat)
+	var badReq *BadRequestError
+	if !errors.As(err, &badReq) {
+		t.Fatalf("NewAPIError(400) = %T, want *BadRequestError", err)
+	}
+	if badReq.Type != "ValidationError" || badReq.RequestID != "req-1" {
+		t.Errorf("type/request ID = %q/%q", badReq.Type, badReq.RequestID)
+	}
+	if len(badReq.Violations) != 1 || badReq.Violations[0].Field != "email" {
+		t.Errorf("Violations = %+v", badReq.Violations)
+	}
+	if !strings.Contains(err.Error(), "email is not a valid email address") {
+		t.Errorf("Error() = %q, want violation", err)
+	}
+
+	wrapped := []byte(`{"error":{"code":409,"message":"Contact already exists","type":"ObjectExists"}}`)
+	err = NewAPIError(409, wrapped)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Message != "Contact already exists" || conflict.Type != "ObjectExists" {
+		t.Errorf("NewAPIError(409) = %#v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 409 {
+		t.Errorf("ConflictError does not unwrap to *APIError: %v", err)
+	}
+
+	var unprocessable *UnprocessableEntityError
+	if err := NewAPIError(422, []byte("not json")); !errors.As(err, &unprocessable) || unprocessable.Message != "unknown error" {
+		t.Errorf("NewAPIError(422) = %#v", err)
+	}
+}
+
+func TestIsProxyClient_Context(t *testing.T) {
+	conn, server := net.Pipe()
+	defer func() { _ = server.Close() }()
+
+	c := NewIsProxyClient("key")
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	go func() {
+		r := bufio.NewReader(server)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			// Answer the first check only; leave the second one hanging.
+			if strings.HasPrefix(line, "CHECK example com") {
+				_, _ = io.WriteString(server, "example.com AVAILABLE 9.95\r\n")
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := c.Check(ctx, "example", "com")
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if !res.Available || res.Price != 9.95 {
+		t.Errorf("result = %+v", res)
+	}
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = c.Check(ctx, "example", "net")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Check took %s after cancel", time.Since(start))
+	}
+}
+
+// fakeIsProxy serves the IsProxy protocol over in-memory connections. Names
+// starting with "free" are available. After dropAfter checks (if set) it
+// drops the connection once, or with skipLine leaves one check unanswered.
+type fakeIsProxy struct {
+	dials     atomic.Int32
+	dropAfter int32
+	skipLine  bool
+	checks    atomic.Int32
+	dropped   atomic.Bool
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (f *fakeIsProxy) dial(context.Context) (net.Conn, error) {
+	f.dials.Add(1)
+	client, server := net.Pipe()
+	f.mu.Lock()
+	f.conns = append(f.conns, server)
+	f.mu.Unlock()
+	go f.serve(server)
+	return client, nil
+}
+
+func (f *fakeIsProxy) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case fields[0] == "AUTH" && fields[1] == "key":
+			_, _ = io.WriteString(conn, "OK\r\n")
+		case fields[0] == "AUTH":
+			_, _ = io.WriteString(conn, "ERR invalid key\r\n")
+		case fields[0] == "CHECK":
+			if n := f.checks.Add(1); f.dropAfter > 0 && n > f.dropAfter && f.dropped.CompareAndSwap(false, true) {
+				if f.skipLine {
+					continue
+				}
+				return
+			}
+			status := "TAKEN"
+			if strings.HasPrefix(fields[1], "free") {
+				status = "AVAILABLE 9.95"
+			}
+			_, _ = io.WriteString(conn, fields[1]+"."+fields[2]+" "+status+"\r\n")
+		case fields[0] == "QUIT":
+			return
+		}
+	}
+}
+
+// closeAll closes the server side of every connection.
+func (f *fakeIsProxy) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.conns {
+		_ = c.Close()
+	}
+}
+
+func isProxyDomains(n int) []string {
+	domains := make([]string, n)
+	for i := range domains {
+		prefix := "taken"
+		if i%3 == 0 {
+			prefix = "free"
+		}
+		domains[i] = fmt.Sprintf("%s%d.com", prefix, i)
+	}
+	return domains
+}
+
+func checkIsProxyResults(t *testing.T, domains []string, results []IsProxyResult) {
+	t.Helper()
+	if len(results) != len(domains) {
+		t.Fatalf("got %d results, want %d", len(results), len(domains))
+	}
+	for i, r := range results {
+		if r.Domain+"."+r.TLD != domains[i] {
+			t.Errorf("result %d is %s.%s, want %s", i, r.Domain, r.TLD, domains[i])
+		}
+		if want := i%3 == 0; r.Available != want {
+			t.Errorf("%s available = %v, want %v", domains[i], r.Available, want)
+		}
+	}
+}
+
+func TestIsProxyPool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("parallel and reused", func(t *testing.T) {
+		fake := &fakeIsProxy{}
+		pool := NewIsProxyPool("key", 3)
+		pool.dial = fake.dial
+		defer func() { _ = pool.Close() }()
+
+		domains := isProxyDomains(45)
+		for range 2 {
+			results, err := pool.CheckMany(ctx, domains)
+			if err != nil {
+				t.Fatalf("CheckMany: %v", err)
+			}
+			checkIsProxyResults(t, domains, results)
+		}
+		if n := fake.dials.Load(); n > 3 {
+			t.Errorf("dialled %d connections, want at most 3", n)
+		}
+	})
+
+	t.Run("reconnects after a dropped connection", func(t *testing.T) {
+		fake := &fakeIsProxy{dropAfter: 7}
+		pool := NewIsProxyPool("key", 2)
+		pool.dial = fake.dial
+		defer func() { _ = pool.Close() }()
+
+		domains := isProxyDomains(30)
+		results, err := pool.CheckMany(ctx, domains)
+		if err != nil {
+			t.Fatalf("CheckMany: %v", err)
+		}
+		checkIsProxyResults(t, domains, results)
+		if !fake.dropped.Load() || fake.dials.Load() < 2 {
+			t.Errorf("dropped = %v after %d dials, want a reconnect", fake.dropped.Load(), fake.dials.Load())
+		}
+	})
+
+	t.Run("reconnects after a missing response", func(t *testing.T) {
+		fake := &fakeIsProxy{dropAfter: 4, skipLine: true}
+		pool := NewIsProxyPool("key", 1)
+		pool.dial = fake.dial
+		defer func() { _ = pool.Close() }()
+
+		domains := isProxyDomains(10)
+		results, err := pool.CheckMany(ctx, domains)
+		if err != nil {
+			t.Fatalf("CheckMany: %v", err)
+		}
+		checkIsProxyResults(t, domains, results)
+		if n := fake.dials.Load(); n != 2 {
+			t.Errorf("dialled %d connections, want 2", n)
+		}
+	})
+
+	t.Run("replaces closed idle connections", func(t *testing.T) {
+		fake := &fakeIsProxy{}
+		pool := NewIsProxyPool("key", 1)
+		pool.dial = fake.dial
+		defer func() { _ = pool.Close() }()
+
+		domains := isProxyDomains(5)
+		if _, err := pool.CheckMany(ctx, domains); err != nil {
+			t.Fatalf("CheckMany: %v", err)
+		}
+		fake.closeAll()
+
+		results, err := pool.CheckMany(ctx, domains)
+		if err != nil {
+			t.Fatalf("CheckMany after close: %v", err)
+		}
+		checkIsProxyResults(t, domains, results)
+		if n := fake.dials.Load(); n != 2 {
+			t.Errorf("dialled %d connections, want 2", n)
+		}
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		fake := &fakeIsProxy{}
+		pool := NewIsProxyPool("wrong", 1)
+		pool.dial = fake.dial
+
+		// Three batches, but the failed login must not be retried for each.
+		_, err := pool.CheckMany(ctx, isProxyDomains(3*isProxyPipelineDepth))
+		if !errors.Is(err, errIsProxyAuth) {
+			t.Errorf("err = %v, want errIsProxyAuth", err)
+		}
+		if n := fake.dials.Load(); n != 1 {
+			t.Errorf("dials = %d, want 1", n)
+		}
+	})
+}
+
+func TestIsProxyClient_OutOfSync(t *testing.T) {
+	fake := &fakeIsProxy{dropAfter: 1, skipLine: true}
+	c := NewIsProxyClient("key")
+	c.dial = fake.dial
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = c.conn.Close() }()
+
+	results, err := c.CheckMany(context.Background(), []string{"free1.com", "taken2.com", "taken3.com"})
+	if !errors.Is(err, errIsProxyOutOfSync) {
+		t.Fatalf("err = %v, want errIsProxyOutOfSync", err)
+	}
+	if len(results) != 1 || results[0].Domain != "free1" {
+		t.Errorf("results = %+v, want only free1.com", results)
+	}
+}
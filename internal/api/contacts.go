@@ -2,8 +2,12 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/mail"
 	"net/url"
+	"regexp"
+	"strings"
 )
 
 // Contact API endpoints per SPEC.md:
@@ -12,6 +16,8 @@ import (
 // POST   /v2/customers/{customer}/contacts/{handle}  → CreateContact
 // POST   /v2/customers/{customer}/contacts/{handle}/update → UpdateContact
 // DELETE /v2/customers/{customer}/contacts/{handle}  → DeleteContact
+// POST   /v2/customers/{customer}/contacts/{handle}/{registry}        → AddContactProperties
+// POST   /v2/customers/{customer}/contacts/{handle}/{registry}/update → UpdateContactProperties
 
 // ContactRequest for creating/updating contacts.
 type ContactRequest struct {
@@ -27,6 +33,77 @@ type ContactRequest struct {
 	Country      string   `json:"country"` // ISO 2-letter code
 }
 
+// e164Pattern matches +CC.NNNN (the registry format) or plain +CCNNNN.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{0,2}\.?[0-9]{1,14}$`)
+
+// ValidatePhone checks that phone is an E.164 number, optionally with a dot
+// after the country code (e.g. +31.201234567).
+func ValidatePhone(phone string) error {
+	if !e164Pattern.MatchString(phone) {
+		return errors.New("must be in E.164 format, e.g. +31.201234567")
+	}
+	if digits := len(strings.ReplaceAll(phone[1:], ".", "")); digits > 15 {
+		return errors.New("must have at most 15 digits")
+	}
+	return nil
+}
+
+// ValidateEmail checks that email is a bare address (no display name).
+func ValidateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return errors.New("is not a valid email address")
+	}
+	return nil
+}
+
+// ValidateCountry checks that country is an ISO 3166-1 alpha-2 code.
+func ValidateCountry(country string) error {
+	if len(country) != 2 || !IsCountryCode(country) {
+		return errors.New("must be a 2-letter ISO 3166-1 country code")
+	}
+	return nil
+}
+
+// Validate checks required fields and formats before a create request.
+// It returns a *ValidationError for every invalid field.
+func (r *ContactRequest) Validate() error {
+	var errs []error
+	required := func(field, value string) bool {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, &ValidationError{Field: field, Message: "is required"})
+			return false
+		}
+		return true
+	}
+	check := func(field, value string, fn func(string) error) {
+		if err := fn(value); err != nil {
+			errs = append(errs, &ValidationError{Field: field, Message: err.Error()})
+		}
+	}
+
+	required("name", r.Name)
+	if required("email", r.Email) {
+		check("email", r.Email, ValidateEmail)
+	}
+	if required("phone", r.Phone) {
+		check("phone", r.Phone, ValidatePhone)
+	}
+	if r.Fax != "" {
+		check("fax", r.Fax, ValidatePhone)
+	}
+	if len(r.Address) == 0 {
+		errs = append(errs, &ValidationError{Field: "address", Message: "is required"})
+	}
+	required("city", r.City)
+	required("postalCode", r.PostalCode)
+	if required("country", r.Country) {
+		check("country", r.Country, ValidateCountry)
+	}
+
+	return errors.Join(errs...)
+}
+
 // ContactListOptions for filtering contacts.
 type ContactListOptions struct {
 	ListOptions
@@ -60,6 +137,15 @@ func (c *Client) ListContacts(ctx context.Context, customer string, opts Contact
 	return &resp, nil
 }
 
+// ListAllContacts returns every contact matching opts, fetching all pages.
+// Limit and Offset in opts are ignored.
+func (c *Client) ListAllContacts(ctx context.Context, customer string, opts ContactListOptions) ([]Contact, error) {
+	return listAll(func(offset int) (*ListResponse[Contact], error) {
+		opts.Limit, opts.Offset = pageSize, offset
+		return c.ListContacts(ctx, customer, opts)
+	})
+}
+
 // GetContact returns a single contact.
 func (c *Client) GetContact(ctx context.Context, customer, handle string) (*Contact, error) {
 	var contact Contact
@@ -87,3 +173,22 @@ func (c *Client) DeleteContact(ctx context.Context, customer, handle string) err
 	path := fmt.Sprintf("/customers/%s/contacts/%s", url.PathEscape(customer), url.PathEscape(handle))
 	return c.Delete(ctx, path)
 }
+
+// contactPropertiesRequest is the body for adding or updating contact properties.
+type contactPropertiesRequest struct {
+	Properties map[string]string `json:"properties"`
+}
+
+// AddContactProperties adds registry-specific properties to a contact.
+func (c *Client) AddContactProperties(ctx context.Context, customer, handle, registry string, props map[string]string) error {
+	path := fmt.Sprintf("/customers/%s/contacts/%s/%s",
+		url.PathEscape(customer), url.PathEscape(handle), url.PathEscape(registry))
+	return c.Post(ctx, path, &contactPropertiesRequest{Properties: props}, nil)
+}
+
+// UpdateContactProperties replaces a contact's properties for a registry.
+func (c *Client) UpdateContactProperties(ctx context.Context, customer, handle, registry string, props map[string]string) error {
+	path := fmt.Sprintf("/customers/%s/contacts/%s/%s/update",
+		url.PathEscape(customer), url.PathEscape(handle), url.PathEscape(registry))
+	return c.Post(ctx, path, &contactPropertiesRequest{Properties: props}, nil)
+}
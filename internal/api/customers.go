@@ -6,13 +6,13 @@ import (
 )
 
 // Customer API endpoints:
-// GET /v2/customers/{customer}/pricelist → GetPricelist
+// GET /v2/customers/{customer}/pricelist → GetPricelist (cached)
 
 // GetPricelist returns the customer's pricelist.
 func (c *Client) GetPricelist(ctx context.Context, customer string) (*Pricelist, error) {
 	var resp Pricelist
 	path := "/customers/" + url.PathEscape(customer) + "/pricelist"
-	if err := c.Get(ctx, path, &resp); err != nil {
+	if err := c.getCached(ctx, path, PricelistTTL, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -22,6 +22,7 @@ type DomainAvailability struct {
 	Domain    string  `json:"domain"`
 	Premium   bool    `json:"premium,omitempty"`
 	Price     float64 `json:"price,omitempty"`
+	Currency  string  `json:"currency,omitempty"`
 }
 
 // RegisterRequest for domain registration.
@@ -34,6 +35,17 @@ type RegisterRequest struct {
 	Nameservers  []string `json:"ns,omitempty"`
 	AutoRenew    *bool    `json:"autoRenew,omitempty"`
 	PrivacyProxy *bool    `json:"privacyProxy,omitempty"`
+
+	// Billables acknowledges the price of premium registrations.
+	Billables []Billable `json:"billables,omitempty"`
+}
+
+// Billable is a charge the customer accepts with a request.
+type Billable struct {
+	Product  string `json:"product"`
+	Action   string `json:"action"`
+	Quantity int    `json:"quantity"`
+	Amount   int    `json:"amount"` // in cents
 }
 
 // UpdateRequest for domain updates.
@@ -102,6 +114,15 @@ func (c *Client) ListDomains(ctx context.Context, opts DomainListOptions) (*List
 	return &resp, nil
 }
 
+// ListAllDomains returns every domain matching opts, fetching all pages.
+// Limit and Offset in opts are ignored.
+func (c *Client) ListAllDomains(ctx context.Context, opts DomainListOptions) ([]Domain, error) {
+	return listAll(func(offset int) (*ListResponse[Domain], error) {
+		opts.Limit, opts.Offset = pageSize, offset
+		return c.ListDomains(ctx, opts)
+	})
+}
+
 // GetDomain returns a single domain.
 func (c *Client) GetDomain(ctx context.Context, name string) (*Domain, error) {
 	var domain Domain
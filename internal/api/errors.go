@@ -3,21 +3,48 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // APIError represents a generic API error response.
 type APIError struct {
 	StatusCode int
+	Type       string // API error type, e.g. "ObjectExists"
 	Message    string
 	Details    string
+	Violations []ValidationError // per-field problems
+	RequestID  string
+	Attempts   int // requests sent, including retries
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("api error (%d): %s", e.StatusCode, e.Message)
+	msg := e.Message
+	if len(e.Violations) > 0 {
+		parts := make([]string, len(e.Violations))
+		for i, v := range e.Violations {
+			parts[i] = v.Field + " " + v.Message
+		}
+		msg += ": " + strings.Join(parts, "; ")
+	}
+	return fmt.Sprintf("api error (%d): %s%s", e.StatusCode, msg, e.attemptsSuffix())
+}
+
+// apiError gives access to the embedded APIError of every typed error.
+func (e *APIError) apiError() *APIError {
+	return e
 }
 
-// AuthError represents a 401/403 authentication error.
+// attemptsSuffix notes retries in error messages.
+func (e *APIError) attemptsSuffix() string {
+	if e.Attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (after %d attempts)", e.Attempts)
+}
+
+// AuthError represents a 401/403 authentication error. It unwraps to its
+// *APIError, as do the other typed errors below.
 type AuthError struct {
 	APIError
 }
@@ -26,6 +53,8 @@ func (e *AuthError) Error() string {
 	return fmt.Sprintf("authentication failed: %s", e.Message)
 }
 
+func (e *AuthError) Unwrap() error { return &e.APIError }
+
 // RateLimitError represents a 429 rate limit error.
 type RateLimitError struct {
 	APIError
@@ -33,9 +62,11 @@ type RateLimitError struct {
 }
 
 func (e *RateLimitError) Error() string {
-	return fmt.Sprintf("rate limited: retry after %ds", int(e.RetryAfter.Seconds()))
+	return fmt.Sprintf("rate limited: retry after %ds%s", int(e.RetryAfter.Seconds()), e.attemptsSuffix())
 }
 
+func (e *RateLimitError) Unwrap() error { return &e.APIError }
+
 // NotFoundError represents a 404 resource not found error.
 type NotFoundError struct {
 	APIError
@@ -45,6 +76,37 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("not found: %s", e.Message)
 }
 
+func (e *NotFoundError) Unwrap() error { return &e.APIError }
+
+// BadRequestError represents a 400 error: the request was malformed or
+// failed validation. It unwraps to its *APIError.
+type BadRequestError struct {
+	APIError
+}
+
+func (e *BadRequestError) Unwrap() error { return &e.APIError }
+
+// ConflictError represents a 409 error: the object already exists or is in a
+// state that does not allow the change. It unwraps to its *APIError.
+type ConflictError struct {
+	APIError
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict: %s%s", e.Message, e.attemptsSuffix())
+}
+
+func (e *ConflictError) Unwrap() error { return &e.APIError }
+
+// UnprocessableEntityError represents a 422 error: the request was well
+// formed but refused by the registry or business rules. It unwraps to its
+// *APIError.
+type UnprocessableEntityError struct {
+	APIError
+}
+
+func (e *UnprocessableEntityError) Unwrap() error { return &e.APIError }
+
 // ValidationError represents a field validation error.
 type ValidationError struct {
 	Field   string
@@ -55,40 +117,72 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation: %s %s", e.Field, e.Message)
 }
 
-// apiErrorResponse mirrors the RealtimeRegister JSON error format.
-// {
+// apiErrorResponse mirrors both RealtimeRegister JSON error formats, the
+// flat one:
 //
-//	"error": {
-//	    "code": 400,
-//	    "message": "Domain not found"
+//	{
+//	    "type": "ValidationError",
+//	    "message": "Validation of the request failed",
+//	    "violations": [{"field": "email", "message": "is not a valid email address"}],
+//	    "requestId": "..."
 //	}
 //
-// }
+// and the wrapped one:
+//
+//	{
+//	    "error": {
+//	        "code": 400,
+//	        "message": "Domain not found"
+//	    }
+//	}
 type apiErrorResponse struct {
-	Error struct {
-		Message string `json:"message"`
-		Code    int    `json:"code"`
-	} `json:"error"`
+	Type       string            `json:"type"`
+	Message    string            `json:"message"`
+	Detail     string            `json:"detail"`
+	Violations []apiViolation    `json:"violations"`
+	RequestID  string            `json:"requestId"`
+	Error      *apiErrorResponse `json:"error"`
+}
+
+type apiViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // NewAPIError parses a JSON error response body and returns the appropriate typed error.
 func NewAPIError(statusCode int, body []byte) error {
-	var resp apiErrorResponse
-	msg := "unknown error"
-	if err := json.Unmarshal(body, &resp); err == nil && resp.Error.Message != "" {
-		msg = resp.Error.Message
-	}
-
 	base := APIError{
 		StatusCode: statusCode,
-		Message:    msg,
+		Message:    "unknown error",
+	}
+
+	var resp apiErrorResponse
+	if err := json.Unmarshal(body, &resp); err == nil {
+		if resp.Error != nil {
+			resp = *resp.Error
+		}
+		if resp.Message != "" {
+			base.Message = resp.Message
+		}
+		base.Type = resp.Type
+		base.Details = resp.Detail
+		base.RequestID = resp.RequestID
+		for _, v := range resp.Violations {
+			base.Violations = append(base.Violations, ValidationError{Field: v.Field, Message: v.Message})
+		}
 	}
 
 	switch statusCode {
+	case 400:
+		return &BadRequestError{APIError: base}
 	case 401, 403:
 		return &AuthError{APIError: base}
 	case 404:
 		return &NotFoundError{APIError: base}
+	case 409:
+		return &ConflictError{APIError: base}
+	case 422:
+		return &UnprocessableEntityError{APIError: base}
 	case 429:
 		return &RateLimitError{APIError: base, RetryAfter: parseRetryAfter(body)}
 	default:
@@ -96,6 +190,19 @@ func NewAPIError(statusCode int, body []byte) error {
 	}
 }
 
+// annotate records the attempt count and, if the body did not carry one,
+// the request ID header on a typed API error.
+func annotate(err error, attempts int, requestID string) error {
+	if e, ok := err.(interface{ apiError() *APIError }); ok {
+		base := e.apiError()
+		base.Attempts = attempts
+		if base.RequestID == "" {
+			base.RequestID = requestID
+		}
+	}
+	return err
+}
+
 // parseRetryAfter attempts to extract retry-after seconds from the response body.
 func parseRetryAfter(body []byte) time.Duration {
 	var raw map[string]any
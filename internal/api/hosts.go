@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// Host API endpoints:
+// GET    /v2/hosts              → ListHosts
+// GET    /v2/hosts/{host}       → GetHost
+// POST   /v2/hosts/{host}       → CreateHost
+// POST   /v2/hosts/{host}/update → UpdateHost
+// DELETE /v2/hosts/{host}       → DeleteHost
+
+// IP versions for host addresses.
+const (
+	IPv4 = "V4"
+	IPv6 = "V6"
+)
+
+// HostRequest for creating/updating hosts.
+type HostRequest struct {
+	Addresses []HostAddress `json:"addresses"`
+}
+
+// HostListOptions for filtering hosts.
+type HostListOptions struct {
+	ListOptions
+}
+
+// QueryParams builds a URL query string from host list options.
+func (o HostListOptions) QueryParams() string {
+	v := url.Values{}
+	if o.Limit > 0 {
+		v.Set("limit", fmt.Sprintf("%d", o.Limit))
+	}
+	if o.Offset > 0 {
+		v.Set("offset", fmt.Sprintf("%d", o.Offset))
+	}
+	if o.Search != "" {
+		v.Set("q", o.Search)
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// NewHostAddresses builds host addresses from IP strings, detecting the IP version.
+func NewHostAddresses(ips ...string) ([]HostAddress, error) {
+	addrs := make([]HostAddress, 0, len(ips))
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		version := IPv6
+		if ip.To4() != nil {
+			version = IPv4
+		}
+		addrs = append(addrs, HostAddress{IPVersion: version, Address: ip.String()})
+	}
+	return addrs, nil
+}
+
+// ListHosts returns paginated host list.
+func (c *Client) ListHosts(ctx context.Context, opts HostListOptions) (*ListResponse[Host], error) {
+	var resp ListResponse[Host]
+	if err := c.Get(ctx, "/hosts"+opts.QueryParams(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetHost returns a single host.
+func (c *Client) GetHost(ctx context.Context, hostName string) (*Host, error) {
+	var host Host
+	if err := c.Get(ctx, "/hosts/"+url.PathEscape(hostName), &host); err != nil {
+		return nil, err
+	}
+	return &host, nil
+}
+
+// CreateHost creates a new host.
+func (c *Client) CreateHost(ctx context.Context, hostName string, req *HostRequest) error {
+	return c.Post(ctx, "/hosts/"+url.PathEscape(hostName), req, nil)
+}
+
+// UpdateHost replaces the addresses of a host.
+func (c *Client) UpdateHost(ctx context.Context, hostName string, req *HostRequest) error {
+	return c.Post(ctx, "/hosts/"+url.PathEscape(hostName)+"/update", req, nil)
+}
+
+// DeleteHost deletes a host.
+func (c *Client) DeleteHost(ctx context.Context, hostName string) error {
+	return c.Delete(ctx, "/hosts/"+url.PathEscape(hostName))
+}
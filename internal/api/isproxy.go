@@ -2,7 +2,9 @@ package api
 
 import (
 	"bufio"
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -30,8 +32,24 @@ type IsProxyClient struct {
 	conn   net.Conn
 	reader *bufio.Reader
 	apiKey string
+
+	// dial opens the connection; tests replace it.
+	dial func(ctx context.Context) (net.Conn, error)
 }
 
+// isProxyPipelineDepth is how many CHECK commands are sent on a connection
+// before their responses are read.
+const isProxyPipelineDepth = 10
+
+// IsProxy protocol errors.
+var (
+	// errIsProxyAuth is returned when the server rejects the API key.
+	errIsProxyAuth = errors.New("IsProxy auth failed")
+	// errIsProxyOutOfSync is returned when a response is not for the check
+	// it answers; the connection must then be discarded.
+	errIsProxyOutOfSync = errors.New("IsProxy response out of sync")
+)
+
 // IsProxyResult represents a single domain check result.
 type IsProxyResult struct {
 	Domain    string  `json:"domain"`
@@ -42,22 +60,29 @@ type IsProxyResult struct {
 
 // NewIsProxyClient creates a new IsProxy client.
 func NewIsProxyClient(apiKey string) *IsProxyClient {
-	return &IsProxyClient{apiKey: apiKey}
+	return &IsProxyClient{apiKey: apiKey, dial: dialIsProxy}
 }
 
-// Connect establishes a TLS connection to the IsProxy server.
-func (c *IsProxyClient) Connect() error {
-	dialer := &net.Dialer{Timeout: IsProxyTimeout}
-	conn, err := tls.DialWithDialer(dialer, "tcp",
-		fmt.Sprintf("%s:%d", IsProxyHost, IsProxyPort),
-		&tls.Config{MinVersion: tls.VersionTLS12})
+// dialIsProxy opens a TLS connection to the IsProxy server.
+func dialIsProxy(ctx context.Context) (net.Conn, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: IsProxyTimeout},
+		Config:    &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	return dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", IsProxyHost, IsProxyPort))
+}
+
+// Connect establishes and authenticates a TLS connection to the IsProxy
+// server.
+func (c *IsProxyClient) Connect(ctx context.Context) error {
+	conn, err := c.dial(ctx)
 	if err != nil {
 		return fmt.Errorf("connect to IsProxy: %w", err)
 	}
 	c.conn = conn
 	c.reader = bufio.NewReader(conn)
 
-	if err := c.auth(); err != nil {
+	if err := c.auth(ctx); err != nil {
 		_ = c.Close()
 		return err
 	}
@@ -65,6 +90,29 @@ func (c *IsProxyClient) Connect() error {
 	return nil
 }
 
+// withDeadline runs one request/response exchange on the connection. The
+// exchange must finish within IsProxyTimeout and before ctx's deadline, and
+// is aborted if ctx is cancelled; errors then report the context's error.
+func (c *IsProxyClient) withDeadline(ctx context.Context, fn func() error) error {
+	deadline := time.Now().Add(IsProxyTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	// Unblock pending reads and writes as soon as ctx is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	err := fn()
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+	return err
+}
+
 // Close closes the connection after sending QUIT.
 func (c *IsProxyClient) Close() error {
 	if c.conn == nil {
@@ -74,57 +122,141 @@ func (c *IsProxyClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *IsProxyClient) auth() error {
-	if _, err := fmt.Fprintf(c.conn, "AUTH %s\r\n", c.apiKey); err != nil {
-		return fmt.Errorf("send AUTH: %w", err)
-	}
-
-	resp, err := c.reader.ReadString('\n')
+func (c *IsProxyClient) auth(ctx context.Context) error {
+	var resp string
+	err := c.withDeadline(ctx, func() error {
+		if _, err := fmt.Fprintf(c.conn, "AUTH %s\r\n", c.apiKey); err != nil {
+			return fmt.Errorf("send AUTH: %w", err)
+		}
+		var err error
+		if resp, err = c.reader.ReadString('\n'); err != nil {
+			return fmt.Errorf("read AUTH response: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("read AUTH response: %w", err)
+		return err
 	}
 
 	resp = strings.TrimSpace(resp)
 	if resp != "OK" {
-		return fmt.Errorf("IsProxy auth failed: %s", resp)
+		return fmt.Errorf("%w: %s", errIsProxyAuth, resp)
 	}
 
 	return nil
 }
 
 // Check checks a single domain availability.
-func (c *IsProxyClient) Check(domain, tld string) (*IsProxyResult, error) {
-	if _, err := fmt.Fprintf(c.conn, "CHECK %s %s\r\n", domain, tld); err != nil {
-		return nil, fmt.Errorf("send CHECK: %w", err)
+func (c *IsProxyClient) Check(ctx context.Context, domain, tld string) (*IsProxyResult, error) {
+	results, err := c.checkBatch(ctx, []isProxyCheck{{domain, tld}})
+	if err != nil {
+		return nil, err
 	}
+	return &results[0], nil
+}
 
-	resp, err := c.reader.ReadString('\n')
+// CheckMany checks multiple domains, pipelining the checks on the
+// connection. On error it returns the results received so far.
+func (c *IsProxyClient) CheckMany(ctx context.Context, domains []string) ([]IsProxyResult, error) {
+	checks, err := parseIsProxyChecks(domains)
 	if err != nil {
-		return nil, fmt.Errorf("read CHECK response: %w", err)
+		return nil, err
 	}
 
-	return parseCheckResponse(resp)
+	results := make([]IsProxyResult, 0, len(domains))
+	for start := 0; start < len(checks); start += isProxyPipelineDepth {
+		batch, err := c.checkBatch(ctx, checks[start:min(start+isProxyPipelineDepth, len(checks))])
+		results = append(results, batch...)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
 }
 
-// CheckMany checks multiple domains efficiently.
-func (c *IsProxyClient) CheckMany(domains []string) ([]IsProxyResult, error) {
-	results := make([]IsProxyResult, 0, len(domains))
+// isProxyCheck is a domain to check, split into name and TLD.
+type isProxyCheck struct {
+	domain, tld string
+}
 
+// parseIsProxyChecks splits domains into names and TLDs.
+func parseIsProxyChecks(domains []string) ([]isProxyCheck, error) {
+	checks := make([]isProxyCheck, 0, len(domains))
 	for _, full := range domains {
 		parts := strings.SplitN(full, ".", 2)
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("invalid domain format: %q (expected name.tld)", full)
 		}
-		domain, tld := parts[0], parts[1]
+		checks = append(checks, isProxyCheck{parts[0], parts[1]})
+	}
+	return checks, nil
+}
 
-		result, err := c.Check(domain, tld)
-		if err != nil {
-			return results, err
+// checkBatch sends all checks before reading their responses, which the
+// server returns in order; each response must name the domain it answers.
+// Commands are written while responses are read, so neither side blocks on
+// a full buffer. On error it returns the results read so far.
+func (c *IsProxyClient) checkBatch(ctx context.Context, checks []isProxyCheck) ([]IsProxyResult, error) {
+	results := make([]IsProxyResult, 0, len(checks))
+	err := c.withDeadline(ctx, func() error {
+		written := make(chan error, 1)
+		go func() {
+			w := bufio.NewWriter(c.conn)
+			for _, ch := range checks {
+				if _, err := fmt.Fprintf(w, "CHECK %s %s\r\n", ch.domain, ch.tld); err != nil {
+					written <- fmt.Errorf("send CHECK: %w", err)
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				written <- fmt.Errorf("send CHECK: %w", err)
+				return
+			}
+			written <- nil
+		}()
+
+		for _, ch := range checks {
+			resp, err := c.reader.ReadString('\n')
+			if err != nil {
+				// Report a failed write rather than the read it caused.
+				select {
+				case werr := <-written:
+					if werr != nil {
+						return werr
+					}
+				default:
+				}
+				return fmt.Errorf("read CHECK response: %w", err)
+			}
+			result, err := parseCheckResponse(resp)
+			if err != nil {
+				return err
+			}
+			// A lost or reordered line would otherwise shift every later
+			// result onto the wrong domain.
+			if !strings.EqualFold(result.Domain, ch.domain) || !strings.EqualFold(result.TLD, ch.tld) {
+				return fmt.Errorf("%w: got %s.%s, expected %s.%s",
+					errIsProxyOutOfSync, result.Domain, result.TLD, ch.domain, ch.tld)
+			}
+			results = append(results, *result)
 		}
-		results = append(results, *result)
-	}
+		return <-written
+	})
+	return results, err
+}
 
-	return results, nil
+// healthy reports whether an idle connection is still usable: the server
+// has not closed it and sent nothing unrequested.
+func (c *IsProxyClient) healthy() bool {
+	if c.conn == nil || c.reader.Buffered() > 0 {
+		return false
+	}
+	if err := c.conn.SetReadDeadline(time.Now().Add(time.Millisecond)); err != nil {
+		return false
+	}
+	_, err := c.reader.Peek(1)
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 func parseCheckResponse(resp string) (*IsProxyResult, error) {
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultIsProxyConnections is the default size of an IsProxyPool.
+const DefaultIsProxyConnections = 4
+
+// isProxyReconnects is how often a batch of checks is resent on a new
+// connection after its connection failed. Checks change nothing, so they
+// are safe to repeat.
+const isProxyReconnects = 2
+
+// IsProxyPool spreads availability checks over several authenticated
+// IsProxy connections. Connections are opened as needed, kept open between
+// batches and checked before they are reused.
+type IsProxyPool struct {
+	apiKey string
+	size   int
+
+	// dial opens a connection; tests replace it.
+	dial func(ctx context.Context) (net.Conn, error)
+
+	mu   sync.Mutex
+	idle []*IsProxyClient
+}
+
+// NewIsProxyPool creates a pool of at most size connections, or
+// DefaultIsProxyConnections if size is not positive.
+func NewIsProxyPool(apiKey string, size int) *IsProxyPool {
+	if size < 1 {
+		size = DefaultIsProxyConnections
+	}
+	return &IsProxyPool{apiKey: apiKey, size: size, dial: dialIsProxy}
+}
+
+// CheckMany checks domains concurrently over the pool's connections and
+// returns the results in the order of domains. If a check fails for good,
+// it returns the first error and the results that were received, in order.
+func (p *IsProxyPool) CheckMany(ctx context.Context, domains []string) ([]IsProxyResult, error) {
+	checks, err := parseIsProxyChecks(domains)
+	if err != nil {
+		return nil, err
+	}
+
+	// Split the checks into pipelined batches and share them out.
+	type batch struct{ start, end int }
+	var batches []batch
+	for start := 0; start < len(checks); start += isProxyPipelineDepth {
+		batches = append(batches, batch{start, min(start+isProxyPipelineDepth, len(checks))})
+	}
+
+	results := make([]*IsProxyResult, len(checks))
+	errs := make([]error, len(batches))
+
+	queue := make(chan int, len(batches))
+	for i := range batches {
+		queue <- i
+	}
+	close(queue)
+
+	// stop is set once a failure would repeat for every remaining batch.
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	for range min(p.size, len(batches)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var conn *IsProxyClient
+			for i := range queue {
+				if stop.Load() {
+					break
+				}
+				b := batches[i]
+				var got []IsProxyResult
+				got, conn, errs[i] = p.checkBatch(ctx, conn, checks[b.start:b.end])
+				for j := range got {
+					results[b.start+j] = &got[j]
+				}
+				if errors.Is(errs[i], errIsProxyAuth) || ctx.Err() != nil {
+					stop.Store(true)
+				}
+			}
+			if conn != nil {
+				p.put(conn)
+			}
+		}()
+	}
+	wg.Wait()
+
+	out := make([]IsProxyResult, 0, len(results))
+	for _, r := range results {
+		if r != nil {
+			out = append(out, *r)
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// checkBatch runs checks on conn, or on a connection from the pool if conn
+// is nil. When the connection fails it is closed and the remaining checks
+// are resent on a new one. It returns the connection to use for the next
+// batch, which is nil if none is usable.
+func (p *IsProxyPool) checkBatch(ctx context.Context, conn *IsProxyClient, checks []isProxyCheck) ([]IsProxyResult, *IsProxyClient, error) {
+	var results []IsProxyResult
+	var err error
+	for attempt := 0; attempt <= isProxyReconnects; attempt++ {
+		if conn == nil {
+			if conn, err = p.get(ctx); err != nil {
+				return results, nil, err
+			}
+		}
+
+		var got []IsProxyResult
+		got, err = conn.checkBatch(ctx, checks[len(results):])
+		results = append(results, got...)
+		if err == nil {
+			return results, conn, nil
+		}
+
+		_ = conn.conn.Close()
+		conn = nil
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return results, nil, err
+}
+
+// get returns a healthy idle connection, or opens a new one.
+func (p *IsProxyPool) get(ctx context.Context) (*IsProxyClient, error) {
+	p.mu.Lock()
+	for len(p.idle) > 0 {
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if c.healthy() {
+			p.mu.Unlock()
+			return c, nil
+		}
+		_ = c.conn.Close()
+	}
+	p.mu.Unlock()
+
+	c := &IsProxyClient{apiKey: p.apiKey, dial: p.dial}
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// put returns a connection to the pool for reuse, closing it if the pool
+// already holds enough.
+func (p *IsProxyPool) put(c *IsProxyClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.idle) >= p.size {
+		_ = c.Close()
+		return
+	}
+	p.idle = append(p.idle, c)
+}
+
+// Close closes every idle connection.
+func (p *IsProxyPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for _, c := range p.idle {
+		errs = append(errs, c.Close())
+	}
+	p.idle = nil
+	return errors.Join(errs...)
+}
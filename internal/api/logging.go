@@ -0,0 +1,385 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLoggedBody caps how much of a body is printed to the debug log.
+const maxLoggedBody = 4096
+
+// redacted replaces secret values in logs and traces.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are masked in logs and traces.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveFields are JSON body fields masked in logs and traces, matched
+// case-insensitively.
+var sensitiveFields = map[string]bool{
+	"authcode": true,
+	"password": true,
+	"secret":   true,
+	"apikey":   true,
+}
+
+// LoggingTransport wraps an http.RoundTripper and logs every request with
+// secrets redacted. Out receives a human-readable log and HAR, if set,
+// records each exchange.
+type LoggingTransport struct {
+	Base http.RoundTripper
+	Out  io.Writer
+	HAR  *HARLog
+
+	mu sync.Mutex // serialises writes to Out
+}
+
+// NewLoggingTransport creates a LoggingTransport. out and har may be nil.
+func NewLoggingTransport(base http.RoundTripper, out io.Writer, har *HARLog) *LoggingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &LoggingTransport{Base: base, Out: out, HAR: har}
+}
+
+// RoundTrip logs the request and its response or error.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.logf("> %s %s\n", req.Method, req.URL)
+	t.logHeaders("> ", req.Header)
+	t.logBody("> ", reqBody)
+
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.logf("< %s %s failed after %s: %v\n", req.Method, req.URL, elapsed.Round(time.Millisecond), err)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return nil, err
+	}
+
+	t.logf("< %s (%s)\n", resp.Status, elapsed.Round(time.Millisecond))
+	t.logHeaders("< ", resp.Header)
+	t.logBody("< ", respBody)
+
+	if t.HAR != nil {
+		t.HAR.add(start, elapsed, req, reqBody, resp, respBody)
+	}
+	return resp, nil
+}
+
+// LogRetry reports a retry scheduled by RetryTransport.
+func (t *LoggingTransport) LogRetry(req *http.Request, attempt int, status int, wait time.Duration) {
+	t.logf("! %s %s returned %d, retry %d in %s\n", req.Method, req.URL, status, attempt, wait.Round(time.Millisecond))
+}
+
+func (t *LoggingTransport) logf(format string, args ...any) {
+	if t.Out == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, format, args...)
+}
+
+func (t *LoggingTransport) logHeaders(prefix string, h http.Header) {
+	for _, hdr := range redactHeaders(h) {
+		t.logf("%s%s: %s\n", prefix, hdr.Name, hdr.Value)
+	}
+}
+
+func (t *LoggingTransport) logBody(prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	text := redactBody(body)
+	if len(text) > maxLoggedBody {
+		text = fmt.Sprintf("%s... (%d bytes)", text[:maxLoggedBody], len(body))
+	}
+	t.logf("%s%s\n", prefix, text)
+}
+
+// peekRequestBody reads the request body without consuming it.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = body.Close() }()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactHeaders returns the headers sorted by name with secrets masked.
+func redactHeaders(h http.Header) []HARHeader {
+	out := []HARHeader{}
+	for _, name := range sortedKeys(h) {
+		for _, v := range h[name] {
+			if sensitiveHeaders[name] {
+				v = maskSecret(v)
+			}
+			out = append(out, HARHeader{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// maskSecret hides a credential, keeping an auth scheme such as "ApiKey".
+func maskSecret(v string) string {
+	if scheme, _, ok := strings.Cut(v, " "); ok {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// redactBody masks sensitive fields in a JSON body. Other bodies are
+// returned unchanged.
+func redactBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	if !redactValue(v) {
+		return string(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// redactValue masks sensitive fields in place and reports whether any were found.
+func redactValue(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if sensitiveFields[strings.ToLower(k)] {
+				if s, ok := val.(string); ok && s != "" {
+					v[k] = redacted
+					found = true
+				}
+				continue
+			}
+			if redactValue(val) {
+				found = true
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if redactValue(val) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func sortedKeys(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HARLog records HTTP exchanges in HTTP Archive (HAR 1.2) format.
+type HARLog struct {
+	mu      sync.Mutex
+	creator HARCreator
+	entries []HAREntry
+}
+
+// NewHARLog creates an empty HAR log for the given tool version.
+func NewHARLog(name, version string) *HARLog {
+	return &HARLog{creator: HARCreator{Name: name, Version: version}, entries: []HAREntry{}}
+}
+
+// HARCreator names the tool that produced a HAR log.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HARHeader is a header or query parameter.
+type HARHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HAREntry is a single request and response.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // milliseconds
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest is the request half of a HAR entry.
+type HARRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Headers     []HARHeader  `json:"headers"`
+	QueryString []HARHeader  `json:"queryString"`
+	PostData    *HARPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+// HARPostData is a request body.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARResponse is the response half of a HAR entry.
+type HARResponse struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []HARHeader `json:"headers"`
+	Content     HARContent  `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+// HARContent is a response body.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// HARTimings splits the entry time. Only the wait is measured.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func (l *HARLog) add(start time.Time, elapsed time.Duration, req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) {
+	ms := float64(elapsed.Microseconds()) / 1000
+
+	entry := HAREntry{
+		StartedDateTime: start,
+		Time:            ms,
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Headers:     redactHeaders(req.Header),
+			QueryString: []HARHeader{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: HARResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     redactHeaders(resp.Header),
+			Content: HARContent{
+				Size:     len(respBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     redactBody(respBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: HARTimings{Wait: ms},
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, HARHeader{Name: name, Value: v})
+		}
+	}
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &HARPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     redactBody(reqBody),
+		}
+	}
+
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.mu.Unlock()
+}
+
+// Entries returns a copy of the recorded entries.
+func (l *HARLog) Entries() []HAREntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]HAREntry(nil), l.entries...)
+}
+
+// WriteTo writes the log as HAR JSON.
+func (l *HARLog) WriteTo(w io.Writer) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc := map[string]any{
+		"log": map[string]any{
+			"version": "1.2",
+			"creator": l.creator,
+			"entries": l.entries,
+		},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// WriteFile writes the log to path, replacing any existing file.
+func (l *HARLog) WriteFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := l.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
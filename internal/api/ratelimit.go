@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request a client sends, so
+// concurrent workers together stay under the configured rate. It also pauses
+// all requests when the API reports the limit is exhausted.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second; 0 means unlimited
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second, with
+// bursts of up to one second's worth. An rps of 0 only applies the pauses
+// requested by the API.
+func NewRateLimiter(rps float64) *RateLimiter {
+	burst := math.Max(1, math.Ceil(rps))
+	return &RateLimiter{
+		rate:   math.Max(rps, 0),
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		d := l.reserve()
+		l.mu.Unlock()
+		if d <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before
+// trying again. l.mu must be held.
+func (l *RateLimiter) reserve() time.Duration {
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Update adapts to the rate-limit headers of a response: a Retry-After on
+// 429 or 503, or an exhausted X-RateLimit-Remaining with its reset time,
+// pauses every request until then.
+func (l *RateLimiter) Update(status int, h http.Header) {
+	now := l.now()
+
+	var until time.Time
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		if ra := parseRetryAfterHeader(h.Get("Retry-After")); ra > 0 {
+			until = now.Add(ra)
+		}
+	}
+	if remaining, ok := headerInt(h, "X-RateLimit-Remaining", "RateLimit-Remaining"); ok && remaining <= 0 {
+		if reset, ok := headerInt(h, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+			if t := resetTime(now, reset); t.After(until) {
+				until = t
+			}
+		}
+	}
+
+	if until.IsZero() {
+		return
+	}
+	l.mu.Lock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.mu.Unlock()
+}
+
+// headerInt returns the first of names present in h as an integer.
+func headerInt(h http.Header, names ...string) (int64, bool) {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// resetTime interprets a rate-limit reset as seconds from now, or as a Unix
+// timestamp if it is too large to be a delay.
+func resetTime(now time.Time, reset int64) time.Time {
+	if reset > 1_000_000_000 {
+		return time.Unix(reset, 0)
+	}
+	return now.Add(time.Duration(reset) * time.Second)
+}
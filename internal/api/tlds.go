@@ -2,12 +2,16 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 )
 
 // TLD API endpoints:
-// GET /v2/tlds       → ListTLDs
-// GET /v2/tlds/{tld} → GetTLD
+// GET /v2/tlds       → ListTLDs (cached)
+// GET /v2/tlds/{tld} → GetTLD (cached)
 
 // TLDListOptions for filtering TLDs.
 type TLDListOptions struct {
@@ -17,7 +21,7 @@ type TLDListOptions struct {
 // ListTLDs returns available TLDs.
 func (c *Client) ListTLDs(ctx context.Context, opts TLDListOptions) (*ListResponse[TLDInfo], error) {
 	var resp ListResponse[TLDInfo]
-	if err := c.Get(ctx, "/tlds"+opts.QueryParams(), &resp); err != nil {
+	if err := c.getCached(ctx, "/tlds"+opts.QueryParams(), TLDTTL, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -26,8 +30,57 @@ func (c *Client) ListTLDs(ctx context.Context, opts TLDListOptions) (*ListRespon
 // GetTLD returns info for a single TLD.
 func (c *Client) GetTLD(ctx context.Context, tld string) (*TLDInfo, error) {
 	var info TLDInfo
-	if err := c.Get(ctx, "/tlds/"+url.PathEscape(tld), &info); err != nil {
+	if err := c.getCached(ctx, "/tlds/"+url.PathEscape(tld), TLDTTL, &info); err != nil {
 		return nil, err
 	}
 	return &info, nil
 }
+
+// ValidateContactProperties checks props against a TLD's property definitions:
+// mandatory properties must be set, enumerated values must be allowed, and
+// unknown properties are rejected. It returns a *ValidationError per problem.
+func ValidateContactProperties(defs []ContactProperty, props map[string]string) error {
+	known := make(map[string]bool, len(defs))
+	var errs []error
+	for _, def := range defs {
+		known[def.Name] = true
+		value, ok := props[def.Name]
+		if !ok || value == "" {
+			if def.Mandatory {
+				errs = append(errs, &ValidationError{Field: def.Name, Message: "is required"})
+			}
+			continue
+		}
+		if len(def.Values) > 0 {
+			if _, ok := def.Values[value]; !ok {
+				errs = append(errs, &ValidationError{
+					Field:   def.Name,
+					Message: fmt.Sprintf("must be one of %s", strings.Join(def.AllowedValues(), ", ")),
+				})
+			}
+		}
+	}
+
+	unknown := make([]string, 0)
+	for name := range props {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, &ValidationError{Field: name, Message: "is not a property of this registry"})
+	}
+
+	return errors.Join(errs...)
+}
+
+// AllowedValues returns the property's allowed values in sorted order.
+func (p ContactProperty) AllowedValues() []string {
+	values := make([]string, 0, len(p.Values))
+	for v := range p.Values {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,10 +18,37 @@ var retryableStatusCodes = map[int]bool{
 	504: true,
 }
 
-// RetryTransport wraps an http.RoundTripper with retry logic and exponential backoff.
+// idempotentMethods may be repeated without changing the result, so they are
+// retried after server errors and connection failures. Other methods (POST
+// creates, renews and deletes here) are only retried when rate limited, as
+// the request was then rejected before being processed.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+}
+
+// Retry defaults.
+const (
+	DefaultMaxRetries = 3
+	DefaultBackoff    = time.Second
+	maxBackoff        = 30 * time.Second
+)
+
+// RetryTransport wraps an http.RoundTripper with retry logic and exponential
+// backoff with jitter.
 type RetryTransport struct {
 	Base       http.RoundTripper
 	MaxRetries int
+	Backoff    time.Duration // first retry delay, doubled for each attempt
+
+	// Limiter, if set, throttles every attempt and learns from the
+	// rate-limit headers of each response.
+	Limiter *RateLimiter
+
+	// OnRetry, if set, is called before waiting to retry a request.
+	OnRetry func(req *http.Request, attempt, status int, wait time.Duration)
 }
 
 // NewRetryTransport creates a RetryTransport with default 3 retries.
@@ -29,48 +58,82 @@ func NewRetryTransport(base http.RoundTripper) *RetryTransport {
 	}
 	return &RetryTransport{
 		Base:       base,
-		MaxRetries: 3,
+		MaxRetries: DefaultMaxRetries,
+		Backoff:    DefaultBackoff,
+		Limiter:    NewRateLimiter(0),
 	}
 }
 
+// attemptsKey is the context key under which RoundTrip counts attempts.
+type attemptsKey struct{}
+
+// withAttemptCounter returns a context in which RetryTransport records the
+// number of attempts made for a request in n.
+func withAttemptCounter(ctx context.Context, n *int) context.Context {
+	return context.WithValue(ctx, attemptsKey{}, n)
+}
+
 // RoundTrip executes the request with retry logic.
 func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	var resp *http.Response
-	var err error
+	counter, _ := req.Context().Value(attemptsKey{}).(*int)
+
+	for attempt := 0; ; attempt++ {
+		if counter != nil {
+			*counter = attempt + 1
+		}
 
-	for attempt := 0; attempt <= t.MaxRetries; attempt++ {
-		resp, err = t.Base.RoundTrip(req)
-		if err != nil {
-			return nil, err
+		r := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The previous attempt consumed the body; send a fresh copy.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
 		}
 
-		if !retryableStatusCodes[resp.StatusCode] {
-			return resp, nil
+		if t.Limiter != nil {
+			if err := t.Limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
 		}
 
-		// Last attempt -- return as-is.
-		if attempt == t.MaxRetries {
-			return resp, nil
+		resp, err := t.Base.RoundTrip(r)
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+			if t.Limiter != nil {
+				t.Limiter.Update(status, resp.Header)
+			}
+		}
+		if attempt >= t.MaxRetries || !t.shouldRetry(req, status, err) {
+			return resp, err
 		}
 
-		// Compute backoff: 1s, 2s, 4s ...
-		backoff := time.Second * time.Duration(1<<min(attempt, 4)) //nolint:gosec // G115: attempt bounded by MaxRetries
+		wait := t.backoff(attempt)
+		if resp != nil {
+			// Respect Retry-After header if present and larger.
+			if ra := parseRetryAfterHeader(resp.Header.Get("Retry-After")); ra > wait {
+				wait = ra
+			}
 
-		// Respect Retry-After header if present and larger.
-		if ra := parseRetryAfterHeader(resp.Header.Get("Retry-After")); ra > backoff {
-			backoff = ra
+			// Drain and close body before retry.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
 		}
 
-		// Drain and close body before retry.
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
+		if t.OnRetry != nil {
+			t.OnRetry(req, attempt+1, status, wait)
+		}
 
 		// Wait for backoff or context cancellation.
 		if req.Context().Err() != nil {
 			return nil, req.Context().Err()
 		}
 
-		timer := time.NewTimer(backoff)
+		timer := time.NewTimer(wait)
 		select {
 		case <-timer.C:
 		case <-req.Context().Done():
@@ -78,8 +141,38 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return nil, req.Context().Err()
 		}
 	}
+}
 
-	return resp, nil
+// shouldRetry reports whether a request that failed with status (0 if
+// there was no response) or err may be sent again.
+func (t *RetryTransport) shouldRetry(req *http.Request, status int, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	// A body that cannot be rewound cannot be resent.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return idempotentMethods[req.Method]
+	}
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return retryableStatusCodes[status] && idempotentMethods[req.Method]
+}
+
+// backoff returns the delay before retry attempt+1: the base backoff doubled
+// per attempt, capped, with up to half of it randomised so that concurrent
+// clients do not retry in lockstep.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	base := t.Backoff
+	if base <= 0 {
+		base = DefaultBackoff
+	}
+	d := min(base<<min(attempt, 5), maxBackoff) //nolint:gosec // G115: attempt bounded above
+	half := d / 2
+	return half + rand.N(half+1) //nolint:gosec // G404: jitter needs no crypto randomness
 }
 
 // parseRetryAfterHeader parses a Retry-After header value as either
@@ -3,7 +3,9 @@ package api
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,6 +22,25 @@ type Pagination struct {
 	Total  int `json:"total"`
 }
 
+// pageSize is the page size used when fetching every page of a list.
+const pageSize = 100
+
+// listAll calls fetch with increasing offsets until all entities are collected.
+func listAll[T any](fetch func(offset int) (*ListResponse[T], error)) ([]T, error) {
+	var all []T
+	for offset := 0; ; {
+		resp, err := fetch(offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Entities...)
+		offset += len(resp.Entities)
+		if len(resp.Entities) == 0 || offset >= resp.Pagination.Total {
+			return all, nil
+		}
+	}
+}
+
 // ListOptions for paginated requests.
 type ListOptions struct {
 	Limit  int
@@ -90,6 +111,23 @@ type Contact struct {
 	State        string    `json:"state,omitempty"`
 	Country      string    `json:"country"`
 	CreatedDate  time.Time `json:"createdDate"`
+
+	// Properties holds registry-specific properties keyed by registry.
+	Properties map[string]map[string]string `json:"properties,omitempty"`
+}
+
+// Host represents a nameserver host object with glue addresses.
+type Host struct {
+	HostName    string        `json:"hostName"`
+	Addresses   []HostAddress `json:"addresses,omitempty"`
+	CreatedDate time.Time     `json:"createdDate"`
+	UpdatedDate time.Time     `json:"updatedDate,omitempty"`
+}
+
+// HostAddress is a glue IP address for a host.
+type HostAddress struct {
+	IPVersion string `json:"ipVersion"` // "V4" (IPv4) or "V6" (IPv6)
+	Address   string `json:"address"`
 }
 
 // Zone represents a DNS zone.
@@ -99,7 +137,12 @@ type Zone struct {
 	Customer    string      `json:"customer"`
 	Service     string      `json:"service,omitempty"`
 	TTL         int         `json:"ttl"`
+	HostMaster  string      `json:"hostMaster,omitempty"`
+	Refresh     int         `json:"refresh,omitempty"`
+	Retry       int         `json:"retry,omitempty"`
+	Expire      int         `json:"expire,omitempty"`
 	DNSSec      bool        `json:"dnssec"`
+	DNSSecMode  string      `json:"dnssecMode,omitempty"`
 	Records     []DNSRecord `json:"records,omitempty"`
 	CreatedDate time.Time   `json:"createdDate"`
 	UpdatedDate time.Time   `json:"updatedDate,omitempty"`
@@ -139,6 +182,21 @@ type TLDInfo struct {
 	PriceTransfer float64 `json:"priceTransfer"`
 	MinPeriod     int     `json:"minPeriod"`
 	MaxPeriod     int     `json:"maxPeriod"`
+
+	// Registry is the registry (provider) that operates the TLD. Contact
+	// properties are stored per registry.
+	Registry          string            `json:"registry,omitempty"`
+	ContactProperties []ContactProperty `json:"contactProperties,omitempty"`
+}
+
+// ContactProperty describes a registry-specific contact property a TLD accepts.
+type ContactProperty struct {
+	Name        string            `json:"name"`
+	Label       string            `json:"label,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Type        string            `json:"type,omitempty"` // e.g. "string", "enum", "boolean"
+	Mandatory   bool              `json:"mandatory"`
+	Values      map[string]string `json:"values,omitempty"` // allowed value → label
 }
 
 // PricelistEntry represents a price from the customer pricelist.
@@ -154,13 +212,108 @@ type Pricelist struct {
 	Prices []PricelistEntry `json:"prices"`
 }
 
+// Pricelist actions.
+const (
+	ActionCreate   = "CREATE"
+	ActionRenew    = "RENEW"
+	ActionTransfer = "TRANSFER"
+	ActionRestore  = "RESTORE"
+)
+
+// domainProductPrefix prefixes TLD names in pricelist product names.
+const domainProductPrefix = "domain_"
+
+// DomainProduct returns the pricelist product name for a TLD, e.g.
+// "domain_com".
+func DomainProduct(tld string) string {
+	return domainProductPrefix + strings.TrimPrefix(strings.ToLower(tld), ".")
+}
+
 // GetTLDPrice finds the CREATE price for a TLD in cents, returns price and currency.
 func (p *Pricelist) GetTLDPrice(tld string) (price int, currency string, found bool) {
-	product := "domain_" + tld
+	return p.Price(tld, ActionCreate)
+}
+
+// Price finds the price in cents for a TLD and action (e.g. ActionRenew).
+// If the pricelist has several currencies, the first match is returned.
+func (p *Pricelist) Price(tld, action string) (price int, currency string, found bool) {
+	product := DomainProduct(tld)
 	for _, entry := range p.Prices {
-		if entry.Product == product && entry.Action == "CREATE" {
+		if entry.Product == product && strings.EqualFold(entry.Action, action) {
 			return entry.Price, entry.Currency, true
 		}
 	}
 	return 0, "", false
 }
+
+// PriceIn finds the price in cents for a TLD and action in currency.
+func (p *Pricelist) PriceIn(tld, action, currency string) (price int, found bool) {
+	product := DomainProduct(tld)
+	for _, entry := range p.Prices {
+		if entry.Product == product && strings.EqualFold(entry.Action, action) && strings.EqualFold(entry.Currency, currency) {
+			return entry.Price, true
+		}
+	}
+	return 0, false
+}
+
+// Currencies returns the currencies the pricelist has prices in, sorted.
+func (p *Pricelist) Currencies() []string {
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, entry := range p.Prices {
+		if cur := strings.ToUpper(entry.Currency); cur != "" && !seen[cur] {
+			seen[cur] = true
+			currencies = append(currencies, cur)
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// TLDPrice is the price of each domain action for one TLD and currency, in
+// cents. A missing action is -1.
+type TLDPrice struct {
+	TLD      string `json:"tld"`
+	Currency string `json:"currency"`
+	Create   int    `json:"create"`
+	Renew    int    `json:"renew"`
+	Transfer int    `json:"transfer"`
+	Restore  int    `json:"restore"`
+}
+
+// ByTLD groups the domain prices by TLD and currency, sorted by TLD.
+func (p *Pricelist) ByTLD() []TLDPrice {
+	byKey := make(map[string]*TLDPrice)
+	var keys []string
+	for _, entry := range p.Prices {
+		tld, ok := strings.CutPrefix(entry.Product, domainProductPrefix)
+		if !ok {
+			continue
+		}
+		key := tld + " " + entry.Currency
+		tp, ok := byKey[key]
+		if !ok {
+			tp = &TLDPrice{TLD: tld, Currency: entry.Currency, Create: -1, Renew: -1, Transfer: -1, Restore: -1}
+			byKey[key] = tp
+			keys = append(keys, key)
+		}
+		switch strings.ToUpper(entry.Action) {
+		case ActionCreate:
+			tp.Create = entry.Price
+		case ActionRenew:
+			tp.Renew = entry.Price
+		case ActionTransfer:
+			tp.Transfer = entry.Price
+		case ActionRestore:
+			tp.Restore = entry.Price
+		}
+	}
+
+	sort.Strings(keys)
+	prices := make([]TLDPrice, 0, len(keys))
+	for _, k := range keys {
+		prices = append(prices, *byKey[k])
+	}
+	return prices
+}
@@ -17,6 +17,7 @@ import (
 type ZoneRequest struct {
 	Name       string      `json:"name,omitempty"`
 	TTL        int         `json:"defaultTtl,omitempty"`
+	HostMaster string      `json:"hostMaster,omitempty"`
 	Refresh    int         `json:"refresh,omitempty"`
 	Retry      int         `json:"retry,omitempty"`
 	Expire     int         `json:"expire,omitempty"`
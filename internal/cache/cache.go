@@ -0,0 +1,172 @@
+// Package cache stores API responses on disk with an expiry time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const entrySuffix = ".json"
+
+// Cache is a directory of cached entries, one file per key.
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+// New returns a cache stored in dir. The directory is created on first write.
+func New(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// entry is the on-disk format of a cached value.
+type entry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+	Data    []byte    `json:"data"`
+}
+
+// path returns the file for key. Keys are hashed so they may contain
+// anything, including URLs and account identifiers.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+entrySuffix)
+}
+
+// Get returns the value stored for key if it has not expired. Expired or
+// unreadable entries are removed.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	p := c.path(key)
+	e, err := readEntry(p)
+	if err != nil {
+		return nil, false
+	}
+	if e.Key != key || !c.now().Before(e.Expires) {
+		_ = os.Remove(p)
+		return nil, false
+	}
+	return e.Data, true
+}
+
+// Set stores data for key until ttl has passed.
+func (c *Cache) Set(key string, data []byte, ttl time.Duration) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	raw, err := json.Marshal(entry{Key: key, Expires: c.now().Add(ttl), Data: data})
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every entry and returns how many were removed.
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return n, fmt.Errorf("clear cache: %w", err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Info summarises the cache contents.
+type Info struct {
+	Dir     string `json:"dir"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// Info reports the number and size of cached entries.
+func (c *Cache) Info() (*Info, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Dir: c.dir}
+	now := c.now()
+	for _, f := range files {
+		st, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		info.Entries++
+		info.Bytes += st.Size()
+		if e, err := readEntry(f); err != nil || !now.Before(e.Expires) {
+			info.Expired++
+		}
+	}
+	return info, nil
+}
+
+// files lists the entry files in the cache directory.
+func (c *Cache) files() ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read cache dir: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && strings.HasSuffix(e.Name(), entrySuffix) {
+			files = append(files, filepath.Join(c.dir, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+func readEntry(path string) (*entry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New(dir)
+	c.now = func() time.Time { return now }
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get() on empty cache returned a value")
+	}
+	info, err := c.Info()
+	if err != nil || info.Entries != 0 {
+		t.Fatalf("Info() on missing dir = %+v, %v", info, err)
+	}
+
+	if err := c.Set("https://api.example/v2 /tlds/com", []byte(`{"tld":"com"}`), time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := c.Set("short", []byte("x"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := c.Get("https://api.example/v2 /tlds/com")
+	if !ok || string(got) != `{"tld":"com"}` {
+		t.Errorf("Get() = %q, %v", got, ok)
+	}
+
+	now = now.Add(30 * time.Minute)
+	info, err = c.Info()
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.Entries != 2 || info.Expired != 1 || info.Bytes == 0 {
+		t.Errorf("Info() = %+v, want 2 entries, 1 expired", info)
+	}
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get() returned an expired entry")
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("expired entry not removed, %d files left", len(files))
+	}
+
+	n, err := c.Clear()
+	if err != nil || n != 1 {
+		t.Errorf("Clear() = %d, %v, want 1", n, err)
+	}
+	if _, ok := c.Get("https://api.example/v2 /tlds/com"); ok {
+		t.Error("Get() after Clear() returned a value")
+	}
+}
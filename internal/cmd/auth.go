@@ -5,8 +5,6 @@ import (
 	"os"
 
 	"golang.org/x/term"
-
-	"github.com/dedene/realtime-register-cli/internal/auth"
 )
 
 // AuthCmd manages API key.
@@ -32,7 +30,7 @@ func (c *AuthLoginCmd) Run(_ *RootFlags) error {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("API key cannot be empty")}
 	}
 
-	store, err := auth.NewStore("")
+	store, err := openKeyring()
 	if err != nil {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("open keyring: %w", err)}
 	}
@@ -54,7 +52,7 @@ func (c *AuthStatusCmd) Run(_ *RootFlags) error {
 		return nil
 	}
 
-	store, err := auth.NewStore("")
+	store, err := openKeyring()
 	if err != nil {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("open keyring: %w", err)}
 	}
@@ -76,7 +74,7 @@ func (c *AuthStatusCmd) Run(_ *RootFlags) error {
 type AuthLogoutCmd struct{}
 
 func (c *AuthLogoutCmd) Run(_ *RootFlags) error {
-	store, err := auth.NewStore("")
+	store, err := openKeyring()
 	if err != nil {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("open keyring: %w", err)}
 	}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dedene/realtime-register-cli/internal/cache"
+	"github.com/dedene/realtime-register-cli/internal/config"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// CacheCmd manages the on-disk cache of pricelists and TLD metadata.
+type CacheCmd struct {
+	Info  CacheInfoCmd  `cmd:"" help:"Show cache location and size"`
+	Clear CacheClearCmd `cmd:"" help:"Remove all cached responses"`
+}
+
+func openCache() (*cache.Cache, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return nil, &ExitError{Code: CodeError, Err: err}
+	}
+	return cache.New(dir), nil
+}
+
+// CacheInfoCmd shows cache statistics.
+type CacheInfoCmd struct{}
+
+func (c *CacheInfoCmd) Run(flags *RootFlags) error {
+	cc, err := openCache()
+	if err != nil {
+		return err
+	}
+	info, err := cc.Info()
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+	return f.OutputSingle(info, [][2]string{
+		{"Directory", info.Dir},
+		{"Entries", fmt.Sprintf("%d", info.Entries)},
+		{"Expired", fmt.Sprintf("%d", info.Expired)},
+		{"Size", formatBytes(info.Bytes)},
+	})
+}
+
+// CacheClearCmd removes all cached responses.
+type CacheClearCmd struct{}
+
+func (c *CacheClearCmd) Run(_ *RootFlags) error {
+	cc, err := openCache()
+	if err != nil {
+		return err
+	}
+	n, err := cc.Clear()
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+	fmt.Printf("Removed %d cached response(s).\n", n)
+	return nil
+}
+
+// formatBytes renders a size such as "12.3 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
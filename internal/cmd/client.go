@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/auth"
+	"github.com/dedene/realtime-register-cli/internal/cache"
+	"github.com/dedene/realtime-register-cli/internal/config"
+)
+
+// defaultHTTPTimeout is used when neither flags, environment nor config set one.
+const defaultHTTPTimeout = 30 * time.Second
+
+// clientSettings are the resolved options for constructing an API client.
+type clientSettings struct {
+	Endpoint  string
+	Timeout   time.Duration
+	Retries   int
+	Backoff   time.Duration
+	RateLimit float64 // requests per second; 0 for none
+	UserAgent string
+}
+
+// resolveClientSettings applies flags (which include their environment
+// variables), then the config file, then defaults.
+func resolveClientSettings(flags *RootFlags, cfg *config.File) (*clientSettings, error) {
+	s := &clientSettings{
+		Timeout:   defaultHTTPTimeout,
+		Retries:   api.DefaultMaxRetries,
+		Backoff:   api.DefaultBackoff,
+		UserAgent: "rr/" + version,
+	}
+
+	endpoint, err := resolveEndpoint(firstNonEmpty(flags.Endpoint, cfg.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+	s.Endpoint = endpoint
+
+	switch {
+	case flags.HTTPTimeout > 0:
+		s.Timeout = flags.HTTPTimeout
+	case cfg.HTTPTimeout != "":
+		d, err := time.ParseDuration(cfg.HTTPTimeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid http_timeout %q in config", cfg.HTTPTimeout)
+		}
+		s.Timeout = d
+	}
+
+	switch {
+	case flags.Retries != nil:
+		s.Retries = *flags.Retries
+	case cfg.Retries != nil:
+		s.Retries = *cfg.Retries
+	}
+	if s.Retries < 0 {
+		return nil, fmt.Errorf("retries must be 0 or more, got %d", s.Retries)
+	}
+
+	switch {
+	case flags.RetryBackoff > 0:
+		s.Backoff = flags.RetryBackoff
+	case cfg.RetryBackoff != "":
+		d, err := time.ParseDuration(cfg.RetryBackoff)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid retry_backoff %q in config", cfg.RetryBackoff)
+		}
+		s.Backoff = d
+	}
+
+	s.RateLimit = cfg.RateLimit
+	if flags.RateLimit > 0 {
+		s.RateLimit = flags.RateLimit
+	}
+	if s.RateLimit < 0 {
+		return nil, fmt.Errorf("rate limit must be 0 or more, got %g", s.RateLimit)
+	}
+
+	return s, nil
+}
+
+// resolveEndpoint maps "production" (the default), "sandbox" or an http(s)
+// URL to an API base URL.
+func resolveEndpoint(endpoint string) (string, error) {
+	switch strings.ToLower(endpoint) {
+	case "", "production":
+		return api.ProductionURL, nil
+	case "sandbox", "ote":
+		return api.SandboxURL, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid endpoint %q (use production, sandbox or an http(s) URL)", endpoint)
+	}
+	return strings.TrimSuffix(endpoint, "/"), nil
+}
+
+// newAPIClient builds the API client for a command. It is bound through Kong,
+// so commands receive it as a Run parameter and never construct their own.
+func newAPIClient(flags *RootFlags) (*api.Client, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, &ExitError{Code: CodeError, Err: err}
+	}
+
+	settings, err := resolveClientSettings(flags, cfg)
+	if err != nil {
+		return nil, &ExitError{Code: CodeUsage, Err: err}
+	}
+
+	apiKey, err := getAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client := api.NewClient(apiKey)
+	client.SetBaseURL(settings.Endpoint)
+	client.SetUserAgent(settings.UserAgent)
+	client.SetTimeout(settings.Timeout)
+	client.SetMaxRetries(settings.Retries)
+	client.SetRetryBackoff(settings.Backoff)
+	client.SetRateLimit(settings.RateLimit)
+
+	if dir, err := config.CacheDir(); err == nil {
+		client.SetCache(cache.New(dir))
+	}
+	if flags.NoCache {
+		// Fresh responses still bring the cache up to date.
+		client = client.Refreshed()
+	}
+
+	var out io.Writer
+	if flags.Verbose {
+		out = os.Stderr
+	}
+	var har *api.HARLog
+	if flags.TraceFile != "" {
+		trace.once.Do(func() {
+			trace.log = api.NewHARLog("rr", version)
+			trace.path = flags.TraceFile
+		})
+		har = trace.log
+	}
+	if out != nil || har != nil {
+		client.EnableLogging(out, har)
+	}
+	return client, nil
+}
+
+// openKeyring opens the credential store with the configured backend.
+func openKeyring() (*auth.Store, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewStore(cfg.KeyringBackend)
+}
+
+// getAPIKey retrieves the API key from RR_API_KEY or the keyring.
+func getAPIKey() (string, error) {
+	if key := os.Getenv("RR_API_KEY"); key != "" {
+		return key, nil
+	}
+	store, err := openKeyring()
+	if err != nil {
+		return "", &ExitError{Code: CodeAuth, Err: fmt.Errorf("not authenticated: %w", err)}
+	}
+	key, err := store.GetAPIKey()
+	if err != nil {
+		return "", &ExitError{Code: CodeAuth, Err: fmt.Errorf("not authenticated: %w", err)}
+	}
+	return key, nil
+}
+
+// trace collects API requests for --trace-file across every client created
+// during a command, and is written out when the command finishes.
+var trace struct {
+	once sync.Once
+	log  *api.HARLog
+	path string
+}
+
+// writeTrace writes the --trace-file log, if any requests were traced.
+func writeTrace() error {
+	if trace.log == nil {
+		return nil
+	}
+	return trace.log.WriteFile(trace.path)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/config"
+)
+
+func TestResolveClientSettings(t *testing.T) {
+	two, five := 2, 5
+
+	tests := []struct {
+		name    string
+		flags   RootFlags
+		cfg     config.File
+		want    clientSettings
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: clientSettings{Endpoint: api.ProductionURL, Timeout: defaultHTTPTimeout, Retries: api.DefaultMaxRetries, Backoff: api.DefaultBackoff},
+		},
+		{
+			name: "config",
+			cfg:  config.File{Endpoint: "sandbox", HTTPTimeout: "1m", Retries: &two, RetryBackoff: "2s", RateLimit: 5},
+			want: clientSettings{Endpoint: api.SandboxURL, Timeout: time.Minute, Retries: 2, Backoff: 2 * time.Second, RateLimit: 5},
+		},
+		{
+			name:  "flags override config",
+			flags: RootFlags{Endpoint: "http://localhost:8080/v2/", HTTPTimeout: 5 * time.Second, Retries: &five, RetryBackoff: time.Second, RateLimit: 2.5},
+			cfg:   config.File{Endpoint: "sandbox", HTTPTimeout: "1m", Retries: &two, RetryBackoff: "2s", RateLimit: 5},
+			want:  clientSettings{Endpoint: "http://localhost:8080/v2", Timeout: 5 * time.Second, Retries: 5, Backoff: time.Second, RateLimit: 2.5},
+		},
+		{
+			name:    "invalid endpoint",
+			flags:   RootFlags{Endpoint: "staging"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config timeout",
+			cfg:     config.File{HTTPTimeout: "soon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveClientSettings(&tt.flags, &tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveClientSettings() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveClientSettings() error = %v", err)
+			}
+			tt.want.UserAgent = "rr/" + version
+			if *got != tt.want {
+				t.Errorf("resolveClientSettings() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"auth", api.NewAPIError(401, nil), CodeAuth},
+		{"rate limit", api.NewAPIError(429, nil), CodeRateLimit},
+		{"not found", fmt.Errorf("get domain: %w", api.NewAPIError(404, nil)), CodeNotFound},
+		{"bad request", api.NewAPIError(400, nil), CodeValidation},
+		{"unprocessable", api.NewAPIError(422, nil), CodeValidation},
+		{"conflict", api.NewAPIError(409, nil), CodeConflict},
+		{"server error", api.NewAPIError(500, nil), CodeAPI},
+		{"deadline", fmt.Errorf("execute request: %w", context.DeadlineExceeded), CodeNetwork},
+		{"dial", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, CodeNetwork},
+		{"other", errors.New("boom"), CodeAPI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(apiExitError(tt.err)); got != tt.want {
+				t.Errorf("exit code = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextError(t *testing.T) {
+	err := errors.New("execute request: context canceled")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := ExitCode(contextError(cancelled, 0, err)); got != CodeCancelled {
+		t.Errorf("cancelled: exit code = %d, want %d", got, CodeCancelled)
+	}
+
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	got := contextError(expired, time.Minute, err)
+	if ExitCode(got) != CodeNetwork || !strings.Contains(got.Error(), "timed out after 1m0s") {
+		t.Errorf("timed out: got %v (exit %d)", got, ExitCode(got))
+	}
+
+	if got := contextError(context.Background(), 0, err); got != err {
+		t.Errorf("active context: got %v, want the original error", got)
+	}
+	if got := contextError(cancelled, 0, nil); got != nil {
+		t.Errorf("no error: got %v", got)
+	}
+}
+
+func TestNewAPIClient(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("RR_API_KEY", "test-key")
+
+	var gotAuth, gotUA string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotUA = r.Header.Get("Authorization"), r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, err := newAPIClient(&RootFlags{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("newAPIClient() error = %v", err)
+	}
+	var out map[string]any
+	if err := client.Get(context.Background(), "/ping", &out); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !strings.Contains(gotAuth, "test-key") {
+		t.Errorf("Authorization = %q, want the RR_API_KEY key", gotAuth)
+	}
+	if !strings.HasPrefix(gotUA, "rr/") {
+		t.Errorf("User-Agent = %q, want rr/<version>", gotUA)
+	}
+}
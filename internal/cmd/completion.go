@@ -27,7 +27,7 @@ const bashCompletion = `_rr() {
     COMPREPLY=()
     cur="${COMP_WORDS[COMP_CWORD]}"
     prev="${COMP_WORDS[COMP_CWORD-1]}"
-    opts="version auth config status domain contact zone process tld completion --help --json --plain --verbose --yes --color --version"
+    opts="version auth config status domain contact zone host process tld price cost cache completion --help --json --plain --verbose --trace-file --endpoint --http-timeout --retries --retry-backoff --rate-limit --timeout --no-cache --yes --color --version"
 
     case "${prev}" in
         rr)
@@ -35,14 +35,18 @@ const bashCompletion = `_rr() {
             return 0
             ;;
         domain)
-            COMPREPLY=( $(compgen -W "list get check register update delete renew" -- ${cur}) )
+            COMPREPLY=( $(compgen -W "list get check check-bulk check-ns register register-bulk preflight update delete renew transfer-in transfer-status" -- ${cur}) )
             return 0
             ;;
         contact)
-            COMPREPLY=( $(compgen -W "list get create update delete" -- ${cur}) )
+            COMPREPLY=( $(compgen -W "list get create update delete usage dedupe properties set-properties" -- ${cur}) )
             return 0
             ;;
         zone)
+            COMPREPLY=( $(compgen -W "list get create update delete sync record apply-template templates verify" -- ${cur}) )
+            return 0
+            ;;
+        host)
             COMPREPLY=( $(compgen -W "list get create update delete" -- ${cur}) )
             return 0
             ;;
@@ -51,9 +55,21 @@ const bashCompletion = `_rr() {
             return 0
             ;;
         tld)
+            COMPREPLY=( $(compgen -W "list get properties" -- ${cur}) )
+            return 0
+            ;;
+        price)
             COMPREPLY=( $(compgen -W "list get" -- ${cur}) )
             return 0
             ;;
+        cost)
+            COMPREPLY=( $(compgen -W "forecast" -- ${cur}) )
+            return 0
+            ;;
+        cache)
+            COMPREPLY=( $(compgen -W "info clear" -- ${cur}) )
+            return 0
+            ;;
         auth)
             COMPREPLY=( $(compgen -W "login status logout" -- ${cur}) )
             return 0
@@ -84,8 +100,12 @@ _rr() {
         'domain:Domain commands'
         'contact:Contact commands'
         'zone:DNS zone commands'
+        'host:Host (glue) commands'
         'process:Process commands'
         'tld:TLD commands'
+        'price:Pricelist commands'
+        'cost:Cost reporting'
+        'cache:Manage the API response cache'
         'completion:Generate shell completions'
     )
 
@@ -94,6 +114,14 @@ _rr() {
         '(-j --json)'{-j,--json}'[Output JSON]' \
         '--plain[Output TSV]' \
         '(-v --verbose)'{-v,--verbose}'[HTTP debug logging]' \
+        '--trace-file[Write a HAR trace of API requests]:file:_files' \
+        '--endpoint[API endpoint]:endpoint:(production sandbox)' \
+        '--http-timeout[Timeout for each API request]:duration' \
+        '--retries[Retries for failed API requests]:count' \
+        '--retry-backoff[Delay before the first retry]:duration' \
+        '--rate-limit[Maximum API requests per second]:rps' \
+        '--timeout[Abort the whole command after this long]:duration' \
+        '--no-cache[Bypass the response cache]' \
         '(-y --yes)'{-y,--yes}'[Skip confirmations]' \
         '--color[Color mode]:mode:(auto always never)' \
         '--version[Print version]' \
@@ -118,15 +146,23 @@ complete -c rr -n "__fish_use_subcommand" -a status -d "Show account status"
 complete -c rr -n "__fish_use_subcommand" -a domain -d "Domain commands"
 complete -c rr -n "__fish_use_subcommand" -a contact -d "Contact commands"
 complete -c rr -n "__fish_use_subcommand" -a zone -d "DNS zone commands"
+complete -c rr -n "__fish_use_subcommand" -a host -d "Host (glue) commands"
 complete -c rr -n "__fish_use_subcommand" -a process -d "Process commands"
 complete -c rr -n "__fish_use_subcommand" -a tld -d "TLD commands"
+complete -c rr -n "__fish_use_subcommand" -a price -d "Pricelist commands"
+complete -c rr -n "__fish_use_subcommand" -a cost -d "Cost reporting"
+complete -c rr -n "__fish_use_subcommand" -a cache -d "Manage the API response cache"
 complete -c rr -n "__fish_use_subcommand" -a completion -d "Generate completions"
 
-complete -c rr -n "__fish_seen_subcommand_from domain" -a "list get check register update delete renew"
-complete -c rr -n "__fish_seen_subcommand_from contact" -a "list get create update delete"
-complete -c rr -n "__fish_seen_subcommand_from zone" -a "list get create update delete"
+complete -c rr -n "__fish_seen_subcommand_from domain" -a "list get check check-bulk check-ns register register-bulk preflight update delete renew transfer-in transfer-status"
+complete -c rr -n "__fish_seen_subcommand_from contact" -a "list get create update delete usage dedupe properties set-properties"
+complete -c rr -n "__fish_seen_subcommand_from zone" -a "list get create update delete sync record apply-template templates verify"
+complete -c rr -n "__fish_seen_subcommand_from host" -a "list get create update delete"
 complete -c rr -n "__fish_seen_subcommand_from process" -a "list get info cancel resend"
-complete -c rr -n "__fish_seen_subcommand_from tld" -a "list get"
+complete -c rr -n "__fish_seen_subcommand_from tld" -a "list get properties"
+complete -c rr -n "__fish_seen_subcommand_from price" -a "list get"
+complete -c rr -n "__fish_seen_subcommand_from cost" -a "forecast"
+complete -c rr -n "__fish_seen_subcommand_from cache" -a "info clear"
 complete -c rr -n "__fish_seen_subcommand_from auth" -a "login status logout"
 complete -c rr -n "__fish_seen_subcommand_from config" -a "get set list path"
 complete -c rr -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
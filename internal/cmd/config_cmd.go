@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -30,8 +32,22 @@ func (c *ConfigGetCmd) Run(_ *RootFlags) error {
 		return &ExitError{Code: CodeError, Err: err}
 	}
 
+	key := strings.ToLower(c.Key)
+	if strings.HasPrefix(key, "register.") {
+		defaults, field, err := registerDefaultsFor(cfg, key, false)
+		if err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+		value, err := defaults.Get(field)
+		if err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+		fmt.Println(value)
+		return nil
+	}
+
 	var value string
-	switch strings.ToLower(c.Key) {
+	switch key {
 	case "customer":
 		value = cfg.Customer
 	case "default_tlds":
@@ -42,6 +58,20 @@ func (c *ConfigGetCmd) Run(_ *RootFlags) error {
 		}
 	case "keyring_backend":
 		value = cfg.KeyringBackend
+	case "endpoint":
+		value = cfg.Endpoint
+	case "http_timeout":
+		value = cfg.HTTPTimeout
+	case "retries":
+		if cfg.Retries != nil {
+			value = strconv.Itoa(*cfg.Retries)
+		}
+	case "retry_backoff":
+		value = cfg.RetryBackoff
+	case "rate_limit":
+		if cfg.RateLimit > 0 {
+			value = strconv.FormatFloat(cfg.RateLimit, 'f', -1, 64)
+		}
 	default:
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("unknown config key: %s", c.Key)}
 	}
@@ -62,16 +92,51 @@ func (c *ConfigSetCmd) Run(_ *RootFlags) error {
 		return &ExitError{Code: CodeError, Err: err}
 	}
 
-	switch strings.ToLower(c.Key) {
-	case "customer":
+	switch key := strings.ToLower(c.Key); {
+	case strings.HasPrefix(key, "register."):
+		defaults, field, err := registerDefaultsFor(cfg, key, true)
+		if err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+		if err := defaults.Set(field, c.Value); err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+	case key == "customer":
 		cfg.Customer = c.Value
-	case "default_tlds":
+	case key == "default_tlds":
 		cfg.DefaultTLDs = strings.Split(c.Value, ",")
-	case "auto_renew":
+	case key == "auto_renew":
 		v := strings.EqualFold(c.Value, "true") || c.Value == "1"
 		cfg.AutoRenew = &v
-	case "keyring_backend":
+	case key == "keyring_backend":
 		cfg.KeyringBackend = c.Value
+	case key == "endpoint":
+		if _, err := resolveEndpoint(c.Value); err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+		cfg.Endpoint = c.Value
+	case key == "http_timeout":
+		if d, err := time.ParseDuration(c.Value); err != nil || d <= 0 {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("invalid http_timeout %q (e.g. 30s, 2m)", c.Value)}
+		}
+		cfg.HTTPTimeout = c.Value
+	case key == "retries":
+		n, err := strconv.Atoi(c.Value)
+		if err != nil || n < 0 {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("invalid retries %q: must be a number of 0 or more", c.Value)}
+		}
+		cfg.Retries = &n
+	case key == "retry_backoff":
+		if d, err := time.ParseDuration(c.Value); err != nil || d <= 0 {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("invalid retry_backoff %q (e.g. 500ms, 2s)", c.Value)}
+		}
+		cfg.RetryBackoff = c.Value
+	case key == "rate_limit":
+		rps, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil || rps < 0 {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("invalid rate_limit %q: must be requests per second, 0 for none", c.Value)}
+		}
+		cfg.RateLimit = rps
 	default:
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("unknown config key: %s", c.Key)}
 	}
@@ -84,6 +149,40 @@ func (c *ConfigSetCmd) Run(_ *RootFlags) error {
 	return nil
 }
 
+// registerDefaultsFor resolves a "register.<field>" or
+// "register.tlds.<tld>.<field>" key to the defaults block and field it names.
+// With create set, missing blocks are added to cfg.
+func registerDefaultsFor(cfg *config.File, key string, create bool) (*config.RegisterDefaults, string, error) {
+	parts := strings.Split(key, ".")
+	root := cfg.Register
+	if root == nil {
+		root = &config.RegisterDefaults{}
+		if create {
+			cfg.Register = root
+		}
+	}
+
+	switch {
+	case len(parts) == 2:
+		return root, parts[1], nil
+	case len(parts) == 4 && parts[1] == "tlds":
+		tld := strings.TrimPrefix(parts[2], ".")
+		d := root.TLDs[tld]
+		if d == nil {
+			d = &config.RegisterDefaults{}
+			if create {
+				if root.TLDs == nil {
+					root.TLDs = make(map[string]*config.RegisterDefaults)
+				}
+				root.TLDs[tld] = d
+			}
+		}
+		return d, parts[3], nil
+	default:
+		return nil, "", fmt.Errorf("unknown config key: %s (use register.<setting> or register.tlds.<tld>.<setting>)", key)
+	}
+}
+
 // ConfigListCmd lists all config values.
 type ConfigListCmd struct{}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
 	"github.com/dedene/realtime-register-cli/internal/output"
@@ -16,6 +17,11 @@ type ContactCmd struct {
 	Create ContactCreateCmd `cmd:"" help:"Create a contact"`
 	Update ContactUpdateCmd `cmd:"" help:"Update a contact"`
 	Delete ContactDeleteCmd `cmd:"" help:"Delete a contact"`
+	Usage  ContactUsageCmd  `cmd:"" help:"List domains using a contact"`
+	Dedupe ContactDedupeCmd `cmd:"" help:"Find and merge duplicate contacts"`
+
+	Properties    ContactPropertiesCmd    `cmd:"" help:"Show registry-specific contact properties"`
+	SetProperties ContactSetPropertiesCmd `cmd:"" name:"set-properties" help:"Set registry-specific contact properties"`
 }
 
 // ContactListCmd lists contacts.
@@ -25,20 +31,12 @@ type ContactListCmd struct {
 	Offset int    `help:"Offset for pagination"`
 }
 
-func (c *ContactListCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ContactListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	customer, err := getCustomer()
 	if err != nil {
 		return err
 	}
 
-	client := api.NewClient(apiKey)
 	opts := api.ContactListOptions{
 		ListOptions: api.ListOptions{
 			Limit:  c.Limit,
@@ -49,7 +47,7 @@ func (c *ContactListCmd) Run(flags *RootFlags) error {
 
 	resp, err := client.ListContacts(ctx, customer, opts)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -75,23 +73,15 @@ type ContactGetCmd struct {
 	Handle string `arg:"" help:"Contact handle"`
 }
 
-func (c *ContactGetCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ContactGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	customer, err := getCustomer()
 	if err != nil {
 		return err
 	}
 
-	client := api.NewClient(apiKey)
 	contact, err := client.GetContact(ctx, customer, c.Handle)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -109,32 +99,25 @@ func (c *ContactGetCmd) Run(flags *RootFlags) error {
 
 // ContactCreateCmd creates a contact.
 type ContactCreateCmd struct {
-	Handle  string   `arg:"" help:"Contact handle (unique ID)"`
-	Name    string   `help:"Full name" required:""`
-	Email   string   `help:"Email address" required:""`
-	Phone   string   `help:"Phone number (E.164 format)" required:""`
-	Address []string `help:"Address lines" required:""`
-	City    string   `help:"City" required:""`
-	Postal  string   `help:"Postal code" required:""`
-	Country string   `help:"Country (2-letter ISO code)" required:""`
-	State   string   `help:"State/province"`
-	Org     string   `help:"Organization name"`
+	Handle      string   `arg:"" optional:"" help:"Contact handle (unique ID)"`
+	Name        string   `help:"Full name"`
+	Email       string   `help:"Email address"`
+	Phone       string   `help:"Phone number (E.164 format)"`
+	Address     []string `help:"Address lines"`
+	City        string   `help:"City"`
+	Postal      string   `help:"Postal code"`
+	Country     string   `help:"Country (2-letter ISO code)"`
+	State       string   `help:"State/province"`
+	Org         string   `help:"Organization name"`
+	FromFile    string   `help:"Create contacts from a YAML or JSON file" type:"existingfile" xor:"source"`
+	Interactive bool     `short:"i" help:"Prompt for every field, using flags as defaults" xor:"source"`
 }
 
-func (c *ContactCreateCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	customer, err := getCustomer()
-	if err != nil {
-		return err
+func (c *ContactCreateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if c.FromFile != "" {
+		return c.runFromFile(ctx, flags, client)
 	}
 
-	client := api.NewClient(apiKey)
 	req := api.ContactRequest{
 		Name:         c.Name,
 		Organization: c.Org,
@@ -144,14 +127,39 @@ func (c *ContactCreateCmd) Run(_ *RootFlags) error {
 		City:         c.City,
 		State:        c.State,
 		PostalCode:   c.Postal,
-		Country:      c.Country,
+		Country:      strings.ToUpper(c.Country),
+	}
+	handle := c.Handle
+
+	if c.Interactive || handle == "" || req.Validate() != nil {
+		if !isTerminal(os.Stdin) {
+			if handle == "" {
+				return &ExitError{Code: CodeError, Err: fmt.Errorf("contact handle is required (or use --from-file)")}
+			}
+			if err := req.Validate(); err != nil {
+				return &ExitError{Code: CodeError, Err: err}
+			}
+		} else {
+			// With nothing given on the command line, walk through every field.
+			all := c.Interactive || (handle == "" && req.Name == "" && req.Email == "")
+			var err error
+			handle, err = runContactWizard(os.Stdin, os.Stdout, handle, &req, all)
+			if err != nil {
+				return &ExitError{Code: CodeError, Err: err}
+			}
+		}
+	}
+
+	customer, err := getCustomer()
+	if err != nil {
+		return err
 	}
 
-	if err := client.CreateContact(ctx, customer, c.Handle, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+	if err := client.CreateContact(ctx, customer, handle, &req); err != nil {
+		return apiExitError(err)
 	}
 
-	fmt.Printf("Contact %s created.\n", c.Handle)
+	fmt.Printf("Contact %s created.\n", handle)
 	return nil
 }
 
@@ -169,20 +177,12 @@ type ContactUpdateCmd struct {
 	Org     string   `help:"Organization name"`
 }
 
-func (c *ContactUpdateCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ContactUpdateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	customer, err := getCustomer()
 	if err != nil {
 		return err
 	}
 
-	client := api.NewClient(apiKey)
 	req := api.ContactRequest{
 		Name:         c.Name,
 		Organization: c.Org,
@@ -196,7 +196,7 @@ func (c *ContactUpdateCmd) Run(_ *RootFlags) error {
 	}
 
 	if err := client.UpdateContact(ctx, customer, c.Handle, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Contact %s updated.\n", c.Handle)
@@ -206,19 +206,25 @@ func (c *ContactUpdateCmd) Run(_ *RootFlags) error {
 // ContactDeleteCmd deletes a contact.
 type ContactDeleteCmd struct {
 	Handle string `arg:"" help:"Contact handle to delete"`
+	Force  bool   `help:"Delete without checking whether domains still use the contact"`
 }
 
-func (c *ContactDeleteCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
+func (c *ContactDeleteCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	customer, err := getCustomer()
 	if err != nil {
 		return err
 	}
 
-	customer, err := getCustomer()
-	if err != nil {
-		return err
+	// Checking usage pages through every domain; --force skips it.
+	if !c.Force {
+		uses, err := lookupContactUsage(ctx, client, c.Handle)
+		if err != nil {
+			return apiExitError(err)
+		}
+		if len(uses) > 0 {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("contact %s is used by %d domain(s): %s (use --force to delete anyway)",
+				c.Handle, len(uses), usedDomainNames(uses, 5))}
+		}
 	}
 
 	if !flags.Yes {
@@ -231,9 +237,8 @@ func (c *ContactDeleteCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	if err := client.DeleteContact(ctx, customer, c.Handle); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Contact %s deleted.\n", c.Handle)
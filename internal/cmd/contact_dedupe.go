@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// ContactDedupeCmd finds near-identical contacts and optionally merges them.
+type ContactDedupeCmd struct {
+	Merge             bool     `help:"Repoint admin, tech and billing contacts using duplicates to the canonical contact"`
+	IncludeRegistrant bool     `help:"With --merge, also repoint registrants (changes domain ownership)"`
+	Keep              []string `help:"Handles to keep as canonical (default: oldest in each group)"`
+	Delete            bool     `help:"Delete duplicates after merging"`
+}
+
+// contactCluster is a group of contacts considered duplicates. Contacts are
+// ordered oldest first; Canonical is the handle the others merge into.
+type contactCluster struct {
+	Canonical string        `json:"canonical"`
+	Contacts  []api.Contact `json:"contacts"`
+}
+
+// duplicates returns the handles that would be merged away.
+func (cl *contactCluster) duplicates() []string {
+	var handles []string
+	for _, ct := range cl.Contacts {
+		if ct.Handle != cl.Canonical {
+			handles = append(handles, ct.Handle)
+		}
+	}
+	return handles
+}
+
+// domainRepoint is a domain update replacing duplicate handles.
+type domainRepoint struct {
+	Domain  string            `json:"domain"`
+	Changes map[string]string `json:"changes"` // role → new handle
+	Update  api.UpdateRequest `json:"-"`
+}
+
+func (c *ContactDedupeCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if c.Delete && !c.Merge {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("--delete requires --merge")}
+	}
+	if c.IncludeRegistrant && !c.Merge {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("--include-registrant requires --merge")}
+	}
+
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	contacts, err := client.ListAllContacts(ctx, customer, api.ContactListOptions{})
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	clusters := groupDuplicateContacts(contacts)
+	if err := chooseCanonical(clusters, c.Keep); err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if len(clusters) == 0 {
+		if f.Mode == output.ModeTable {
+			fmt.Println("No duplicate contacts found.")
+			return nil
+		}
+		return f.Output(clusters, nil, nil)
+	}
+
+	if err := renderContactClusters(f, clusters); err != nil {
+		return err
+	}
+	if !c.Merge {
+		if f.Mode == output.ModeTable {
+			fmt.Printf("\n%d group(s) of duplicates. Run with --merge to repoint domains to the kept contact.\n", len(clusters))
+		}
+		return nil
+	}
+
+	domains, err := client.ListAllDomains(ctx, api.DomainListOptions{})
+	if err != nil {
+		return apiExitError(err)
+	}
+	plan := planContactMerge(domains, clusters, c.IncludeRegistrant)
+
+	var leftovers []string
+	for i := range clusters {
+		leftovers = append(leftovers, clusters[i].duplicates()...)
+	}
+
+	// A duplicate is only deleted if every domain that used it was updated.
+	blocked := make(map[string]bool)
+	keptRegistrants := 0
+	if !c.IncludeRegistrant {
+		duplicate := make(map[string]bool)
+		for _, h := range leftovers {
+			duplicate[strings.ToLower(h)] = true
+		}
+		for i := range domains {
+			if h := strings.ToLower(domains[i].Registrant); duplicate[h] {
+				blocked[h] = true
+				keptRegistrants++
+			}
+		}
+	}
+
+	printMergePlan(os.Stderr, plan)
+	if keptRegistrants > 0 {
+		fmt.Fprintf(os.Stderr, "%d domain(s) keep a duplicate registrant; add --include-registrant to repoint them too.\n", keptRegistrants)
+	}
+	fmt.Fprintf(os.Stderr, "\n%d domain(s) will be updated.\n", len(plan))
+	if !flags.Yes {
+		prompt := "Repoint domains to the kept contacts?"
+		if c.Delete {
+			prompt = fmt.Sprintf("Repoint domains and delete %d duplicate contact(s)?", len(leftovers))
+		}
+		fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	failed := 0
+	for i := range plan {
+		p := &plan[i]
+		if err := client.UpdateDomain(ctx, p.Domain, &p.Update); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", p.Domain, err)
+			failed++
+			for _, h := range originalHandles(domains, p.Domain) {
+				blocked[strings.ToLower(h)] = true
+			}
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: updated %s\n", p.Domain, describeChanges(p.Changes))
+	}
+
+	if c.Delete {
+		for _, h := range leftovers {
+			if blocked[strings.ToLower(h)] {
+				fmt.Fprintf(os.Stderr, "%s: kept, a domain still uses it\n", h)
+				continue
+			}
+			if err := client.DeleteContact(ctx, customer, h); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: delete failed: %v\n", h, err)
+				failed++
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "%s: deleted\n", h)
+		}
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: CodeAPI, Err: fmt.Errorf("%d operation(s) failed", failed)}
+	}
+	return nil
+}
+
+func renderContactClusters(f *output.Formatter, clusters []contactCluster) error {
+	headers := []string{"GROUP", "HANDLE", "NAME", "EMAIL", "CITY", "CREATED", "KEEP"}
+	var rows [][]string
+	for i := range clusters {
+		cl := &clusters[i]
+		for _, ct := range cl.Contacts {
+			keep := ""
+			if ct.Handle == cl.Canonical {
+				keep = "yes"
+			}
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", i+1),
+				ct.Handle,
+				ct.Name,
+				ct.Email,
+				ct.City,
+				ct.CreatedDate.Format("2006-01-02"),
+				keep,
+			})
+		}
+	}
+	return f.Output(clusters, headers, rows)
+}
+
+// groupDuplicateContacts clusters contacts sharing a normalised name, email
+// and address. Only groups of two or more are returned, oldest contact first.
+func groupDuplicateContacts(contacts []api.Contact) []contactCluster {
+	byKey := make(map[string][]api.Contact)
+	for _, ct := range contacts {
+		key := contactDedupeKey(&ct)
+		byKey[key] = append(byKey[key], ct)
+	}
+
+	clusters := []contactCluster{}
+	for _, group := range byKey {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			if !group[i].CreatedDate.Equal(group[j].CreatedDate) {
+				return group[i].CreatedDate.Before(group[j].CreatedDate)
+			}
+			return group[i].Handle < group[j].Handle
+		})
+		clusters = append(clusters, contactCluster{Canonical: group[0].Handle, Contacts: group})
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Canonical < clusters[j].Canonical
+	})
+	return clusters
+}
+
+// contactDedupeKey normalises the fields that identify a contact.
+func contactDedupeKey(ct *api.Contact) string {
+	return strings.Join([]string{
+		normalizeText(ct.Name),
+		strings.ToLower(strings.TrimSpace(ct.Email)),
+		normalizeText(strings.Join(ct.AddressLine, " ")),
+		normalizeText(ct.PostalCode),
+		normalizeText(ct.City),
+		strings.ToUpper(ct.Country),
+	}, "|")
+}
+
+// normalizeText lowercases s and drops punctuation and whitespace.
+func normalizeText(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// chooseCanonical applies --keep handles to their clusters.
+func chooseCanonical(clusters []contactCluster, keep []string) error {
+	for _, h := range keep {
+		found := false
+		for i := range clusters {
+			for _, ct := range clusters[i].Contacts {
+				if strings.EqualFold(ct.Handle, h) {
+					clusters[i].Canonical = ct.Handle
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("--keep %s: handle is not in any duplicate group", h)
+		}
+	}
+	return nil
+}
+
+// planContactMerge returns the domain updates needed to replace every
+// duplicate admin, tech and billing handle with its cluster's canonical
+// handle. Registrants are only replaced if includeRegistrant is set.
+func planContactMerge(domains []api.Domain, clusters []contactCluster, includeRegistrant bool) []domainRepoint {
+	replace := make(map[string]string)
+	for i := range clusters {
+		for _, h := range clusters[i].duplicates() {
+			replace[strings.ToLower(h)] = clusters[i].Canonical
+		}
+	}
+
+	var plan []domainRepoint
+	for i := range domains {
+		d := &domains[i]
+		p := domainRepoint{Domain: d.DomainName, Changes: make(map[string]string)}
+		for _, r := range []struct {
+			role   string
+			handle string
+			field  *string
+		}{
+			{roleRegistrant, d.Registrant, &p.Update.Registrant},
+			{roleAdmin, d.AdminHandle, &p.Update.Admin},
+			{roleTech, d.TechHandle, &p.Update.Tech},
+			{roleBilling, d.BillingHandle, &p.Update.Billing},
+		} {
+			if r.role == roleRegistrant && !includeRegistrant {
+				continue
+			}
+			if to, ok := replace[strings.ToLower(r.handle)]; ok && r.handle != "" {
+				*r.field = to
+				p.Changes[r.role] = to
+			}
+		}
+		if len(p.Changes) > 0 {
+			plan = append(plan, p)
+		}
+	}
+	return plan
+}
+
+// originalHandles returns all contact handles on the named domain.
+func originalHandles(domains []api.Domain, name string) []string {
+	for i := range domains {
+		d := &domains[i]
+		if d.DomainName == name {
+			return []string{d.Registrant, d.AdminHandle, d.TechHandle, d.BillingHandle}
+		}
+	}
+	return nil
+}
+
+// printMergePlan lists the planned contact changes per domain, with
+// registrant changes listed separately.
+func printMergePlan(w io.Writer, plan []domainRepoint) {
+	sections := []struct {
+		title string
+		roles []string
+	}{
+		{"Contact changes", []string{roleAdmin, roleTech, roleBilling}},
+		{"Registrant changes", []string{roleRegistrant}},
+	}
+	for _, sec := range sections {
+		var lines []string
+		for i := range plan {
+			if desc := describeChanges(plan[i].Changes, sec.roles...); desc != "" {
+				lines = append(lines, fmt.Sprintf("  %s: %s", plan[i].Domain, desc))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(w, "\n%s:\n%s\n", sec.title, strings.Join(lines, "\n"))
+		}
+	}
+}
+
+// describeChanges lists the changes for roles, or for every role if none
+// are given.
+func describeChanges(changes map[string]string, roles ...string) string {
+	if len(roles) == 0 {
+		roles = []string{roleRegistrant, roleAdmin, roleTech, roleBilling}
+	}
+	parts := make([]string, 0, len(changes))
+	for _, role := range roles {
+		if h, ok := changes[role]; ok {
+			parts = append(parts, role+" → "+h)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// ContactPropertiesCmd shows a contact's registry-specific properties.
+type ContactPropertiesCmd struct {
+	Handle   string `arg:"" help:"Contact handle"`
+	Registry string `help:"Only show properties for this registry"`
+}
+
+func (c *ContactPropertiesCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	contact, err := client.GetContact(ctx, customer, c.Handle)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	props := contact.Properties
+	if c.Registry != "" {
+		props = map[string]map[string]string{c.Registry: contact.Properties[c.Registry]}
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	headers := []string{"REGISTRY", "PROPERTY", "VALUE"}
+	var rows [][]string
+	for _, registry := range sortedMapKeys(props) {
+		for _, name := range sortedMapKeys(props[registry]) {
+			rows = append(rows, []string{registry, name, props[registry][name]})
+		}
+	}
+
+	if len(rows) == 0 && f.Mode == output.ModeTable {
+		fmt.Printf("Contact %s has no registry properties.\n", c.Handle)
+		return nil
+	}
+	return f.Output(props, headers, rows)
+}
+
+// ContactSetPropertiesCmd sets a contact's properties for one registry.
+type ContactSetPropertiesCmd struct {
+	Handle   string            `arg:"" help:"Contact handle"`
+	Set      map[string]string `help:"Property to set (key=value), repeatable" required:""`
+	Registry string            `help:"Registry the properties apply to" xor:"target" required:""`
+	TLD      string            `help:"Derive the registry from a TLD and validate against its property definitions" xor:"target" required:""`
+}
+
+func (c *ContactSetPropertiesCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	contact, err := client.GetContact(ctx, customer, c.Handle)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	registry := c.Registry
+	existing := contact.Properties[registry]
+
+	if c.TLD != "" {
+		tld, err := client.GetTLD(ctx, strings.TrimPrefix(c.TLD, "."))
+		if err != nil {
+			return apiExitError(err)
+		}
+		if tld.Registry == "" {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("TLD %s does not report its registry; use --registry", tld.TLD)}
+		}
+		registry = tld.Registry
+		existing = contact.Properties[registry]
+
+		merged := mergeProperties(existing, c.Set)
+		if err := api.ValidateContactProperties(tld.ContactProperties, merged); err != nil {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("invalid properties for .%s:\n%w", tld.TLD, err)}
+		}
+	}
+
+	props := mergeProperties(existing, c.Set)
+	if existing == nil {
+		err = client.AddContactProperties(ctx, customer, c.Handle, registry, props)
+	} else {
+		err = client.UpdateContactProperties(ctx, customer, c.Handle, registry, props)
+	}
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	fmt.Printf("Contact %s properties for %s updated.\n", c.Handle, registry)
+	return nil
+}
+
+// mergeProperties returns existing overlaid with set. Empty values in set
+// remove the property.
+func mergeProperties(existing, set map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(set))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range set {
+		if v == "" {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// checkRegistrationProperties validates the registry properties of each
+// contact handle against the TLD's definitions before a registration.
+// Contacts are fetched once even if used in several roles.
+func checkRegistrationProperties(ctx context.Context, client *api.Client, customer string, tld *api.TLDInfo, handles []string) error {
+	if len(tld.ContactProperties) == 0 {
+		return nil
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	for _, h := range handles {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		contact, err := client.GetContact(ctx, customer, h)
+		if err != nil {
+			return err
+		}
+		if err := api.ValidateContactProperties(tld.ContactProperties, contact.Properties[tld.Registry]); err != nil {
+			errs = append(errs, fmt.Errorf("contact %s: %w", h, err))
+		}
+	}
+	if len(errs) > 0 {
+		errs = append(errs, fmt.Errorf("set them with: rr contact set-properties <handle> --tld %s --set key=value", tld.TLD))
+	}
+	return errors.Join(errs...)
+}
+
+// domainTLD returns the part of a domain name after the first dot, so that
+// example.co.uk is under co.uk. IsProxy splits names the same way.
+func domainTLD(domain string) string {
+	if _, tld, ok := strings.Cut(domain, "."); ok {
+		return tld
+	}
+	return domain
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
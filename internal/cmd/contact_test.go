@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
+
+func TestRunContactWizard(t *testing.T) {
+	req := api.ContactRequest{Name: "Jane Doe", Email: "jane@example.com", Country: "nl"}
+	input := strings.Join([]string{
+		"jdoe",          // handle
+		"0201234567",    // phone: rejected
+		"+31.201234567", // phone
+		"Main Street 1", // address line 1
+		"",              // end of address
+		"Amsterdam",     // city
+		"1000AA",        // postal code
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	handle, err := runContactWizard(strings.NewReader(input), &out, "", &req, false)
+	if err != nil {
+		t.Fatalf("runContactWizard() error = %v\noutput:\n%s", err, out.String())
+	}
+
+	if handle != "jdoe" {
+		t.Errorf("handle = %q, want jdoe", handle)
+	}
+	if req.Phone != "+31.201234567" || req.City != "Amsterdam" || req.PostalCode != "1000AA" {
+		t.Errorf("req = %+v", req)
+	}
+	if req.Country != "NL" {
+		t.Errorf("Country = %q, want NL", req.Country)
+	}
+	if !strings.Contains(out.String(), "must be in E.164 format") {
+		t.Errorf("expected phone validation message, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "Full name") {
+		t.Errorf("valid fields should not be prompted:\n%s", out.String())
+	}
+}
+
+func TestRunContactWizard_EOF(t *testing.T) {
+	req := api.ContactRequest{}
+	var out bytes.Buffer
+	if _, err := runContactWizard(strings.NewReader("jdoe\n"), &out, "", &req, true); err != errWizardAborted {
+		t.Errorf("runContactWizard() error = %v, want errWizardAborted", err)
+	}
+}
+
+func TestContactUsage(t *testing.T) {
+	domains := []api.Domain{
+		{DomainName: "a.com", Registrant: "jdoe", AdminHandle: "jdoe", TechHandle: "ops"},
+		{DomainName: "b.com", Registrant: "acme", BillingHandle: "JDOE"},
+		{DomainName: "c.com", Registrant: "acme"},
+	}
+
+	uses := contactUsage(domains, "jdoe")
+	if len(uses) != 2 {
+		t.Fatalf("contactUsage() = %+v, want 2 domains", uses)
+	}
+	if got := strings.Join(uses[0].Roles, ","); got != "registrant,admin" {
+		t.Errorf("a.com roles = %q, want registrant,admin", got)
+	}
+	if got := strings.Join(uses[1].Roles, ","); got != "billing" {
+		t.Errorf("b.com roles = %q, want billing", got)
+	}
+
+	if uses := contactUsage(domains, "nobody"); len(uses) != 0 {
+		t.Errorf("contactUsage(nobody) = %+v, want none", uses)
+	}
+	if got := usedDomainNames(contactUsage(domains, "acme"), 1); got != "b.com and 1 more" {
+		t.Errorf("usedDomainNames() = %q", got)
+	}
+}
+
+func TestGroupDuplicateContacts(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+	contacts := []api.Contact{
+		{Handle: "jdoe2", Name: "Jane  Doe", Email: "JANE@example.com", AddressLine: []string{"Main St. 1"}, PostalCode: "1000 AA", City: "Amsterdam", Country: "NL", CreatedDate: day(5)},
+		{Handle: "jdoe1", Name: "jane doe", Email: "jane@example.com", AddressLine: []string{"Main St 1"}, PostalCode: "1000AA", City: "amsterdam", Country: "nl", CreatedDate: day(1)},
+		{Handle: "jdoe3", Name: "Jane Doe", Email: "jane@example.com", AddressLine: []string{"Other Rd 2"}, PostalCode: "1000AA", City: "Amsterdam", Country: "NL", CreatedDate: day(2)},
+		{Handle: "solo", Name: "John Roe", Email: "john@example.com", CreatedDate: day(3)},
+	}
+
+	clusters := groupDuplicateContacts(contacts)
+	if len(clusters) != 1 {
+		t.Fatalf("groupDuplicateContacts() = %d clusters, want 1", len(clusters))
+	}
+	cl := clusters[0]
+	if cl.Canonical != "jdoe1" {
+		t.Errorf("Canonical = %q, want oldest jdoe1", cl.Canonical)
+	}
+	if got := strings.Join(cl.duplicates(), ","); got != "jdoe2" {
+		t.Errorf("duplicates() = %q, want jdoe2", got)
+	}
+
+	if err := chooseCanonical(clusters, []string{"jdoe2"}); err != nil {
+		t.Fatalf("chooseCanonical() error = %v", err)
+	}
+	if clusters[0].Canonical != "jdoe2" {
+		t.Errorf("Canonical = %q after --keep, want jdoe2", clusters[0].Canonical)
+	}
+	if err := chooseCanonical(clusters, []string{"solo"}); err == nil {
+		t.Error("chooseCanonical(solo) expected error")
+	}
+
+	domains := []api.Domain{
+		{DomainName: "a.com", Registrant: "jdoe1", AdminHandle: "jdoe1", TechHandle: "ops"},
+		{DomainName: "b.com", Registrant: "solo"},
+	}
+	plan := planContactMerge(domains, clusters, false)
+	if len(plan) != 1 || plan[0].Domain != "a.com" {
+		t.Fatalf("planContactMerge() = %+v, want a.com only", plan)
+	}
+	if plan[0].Update.Registrant != "" || plan[0].Update.Admin != "jdoe2" || plan[0].Update.Tech != "" {
+		t.Errorf("Update = %+v, want only admin repointed", plan[0].Update)
+	}
+
+	plan = planContactMerge(domains, clusters, true)
+	if len(plan) != 1 || plan[0].Update.Registrant != "jdoe2" || plan[0].Update.Admin != "jdoe2" {
+		t.Fatalf("planContactMerge(includeRegistrant) = %+v, want registrant and admin repointed", plan)
+	}
+
+	var buf bytes.Buffer
+	printMergePlan(&buf, plan)
+	want := "\nContact changes:\n  a.com: admin → jdoe2\n\nRegistrant changes:\n  a.com: registrant → jdoe2\n"
+	if buf.String() != want {
+		t.Errorf("printMergePlan() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDomainTLD(t *testing.T) {
+	tests := map[string]string{
+		"example.com":    "com",
+		"example.co.uk":  "co.uk",
+		"example.com.au": "com.au",
+		"localhost":      "localhost",
+	}
+	for domain, want := range tests {
+		if got := domainTLD(domain); got != want {
+			t.Errorf("domainTLD(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
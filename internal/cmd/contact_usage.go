@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// Contact roles on a domain.
+const (
+	roleRegistrant = "registrant"
+	roleAdmin      = "admin"
+	roleTech       = "tech"
+	roleBilling    = "billing"
+)
+
+// contactUse is a domain that references a contact, with the roles it fills.
+type contactUse struct {
+	Domain string   `json:"domain"`
+	Roles  []string `json:"roles"`
+}
+
+// ContactUsageCmd lists domains that reference a contact.
+type ContactUsageCmd struct {
+	Handle string `arg:"" help:"Contact handle"`
+}
+
+func (c *ContactUsageCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	uses, err := lookupContactUsage(ctx, client, c.Handle)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if len(uses) == 0 && f.Mode == output.ModeTable {
+		fmt.Printf("Contact %s is not used by any domain.\n", c.Handle)
+		return nil
+	}
+
+	headers := []string{"DOMAIN", "ROLES"}
+	rows := make([][]string, 0, len(uses))
+	for _, u := range uses {
+		rows = append(rows, []string{u.Domain, strings.Join(u.Roles, ", ")})
+	}
+	return f.Output(uses, headers, rows)
+}
+
+// lookupContactUsage scans all domains for references to handle.
+func lookupContactUsage(ctx context.Context, client *api.Client, handle string) ([]contactUse, error) {
+	domains, err := client.ListAllDomains(ctx, api.DomainListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return contactUsage(domains, handle), nil
+}
+
+// contactUsage returns the domains that use handle in any contact role.
+func contactUsage(domains []api.Domain, handle string) []contactUse {
+	uses := []contactUse{}
+	for i := range domains {
+		if roles := contactRoles(&domains[i], handle); len(roles) > 0 {
+			uses = append(uses, contactUse{Domain: domains[i].DomainName, Roles: roles})
+		}
+	}
+	return uses
+}
+
+// contactRoles returns the roles handle fills on d.
+func contactRoles(d *api.Domain, handle string) []string {
+	var roles []string
+	for _, r := range []struct {
+		role   string
+		handle string
+	}{
+		{roleRegistrant, d.Registrant},
+		{roleAdmin, d.AdminHandle},
+		{roleTech, d.TechHandle},
+		{roleBilling, d.BillingHandle},
+	} {
+		if r.handle != "" && strings.EqualFold(r.handle, handle) {
+			roles = append(roles, r.role)
+		}
+	}
+	return roles
+}
+
+// usedDomainNames returns up to limit domain names, noting how many were left out.
+func usedDomainNames(uses []contactUse, limit int) string {
+	names := make([]string, 0, min(len(uses), limit))
+	for i, u := range uses {
+		if i == limit {
+			break
+		}
+		names = append(names, u.Domain)
+	}
+	s := strings.Join(names, ", ")
+	if extra := len(uses) - limit; extra > 0 {
+		s += fmt.Sprintf(" and %d more", extra)
+	}
+	return s
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// contactFileEntry is one contact in a --from-file document. Keys match the
+// API's contact JSON, so `rr contact get --json` output can be fed back in.
+type contactFileEntry struct {
+	Handle       string   `yaml:"handle"`
+	Name         string   `yaml:"name"`
+	Organization string   `yaml:"organization"`
+	Email        string   `yaml:"email"`
+	Phone        string   `yaml:"voice"`
+	Fax          string   `yaml:"fax"`
+	AddressLine  []string `yaml:"addressLine"`
+	City         string   `yaml:"city"`
+	State        string   `yaml:"state"`
+	PostalCode   string   `yaml:"postalCode"`
+	Country      string   `yaml:"country"`
+}
+
+func (e *contactFileEntry) request() api.ContactRequest {
+	return api.ContactRequest{
+		Name:         e.Name,
+		Organization: e.Organization,
+		Email:        e.Email,
+		Phone:        e.Phone,
+		Fax:          e.Fax,
+		Address:      e.AddressLine,
+		City:         e.City,
+		State:        e.State,
+		PostalCode:   e.PostalCode,
+		Country:      strings.ToUpper(e.Country),
+	}
+}
+
+// loadContactFile parses a YAML or JSON file holding one contact or a list.
+func loadContactFile(path string) ([]contactFileEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is user-provided CLI arg
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s: no contacts found", path)
+	}
+
+	var entries []contactFileEntry
+	if doc.Content[0].Kind == yaml.SequenceNode {
+		err = doc.Content[0].Decode(&entries)
+	} else {
+		var entry contactFileEntry
+		err = doc.Content[0].Decode(&entry)
+		entries = []contactFileEntry{entry}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: no contacts found", path)
+	}
+	return entries, nil
+}
+
+// runFromFile validates every contact in the file up front, then creates them
+// in order and reports the outcome for each.
+func (c *ContactCreateCmd) runFromFile(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	entries, err := loadContactFile(c.FromFile)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	var errs []error
+	seen := make(map[string]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		label := fmt.Sprintf("contact %d", i+1)
+		if e.Handle == "" {
+			errs = append(errs, fmt.Errorf("%s: handle is required", label))
+			continue
+		}
+		label = e.Handle
+		if seen[e.Handle] {
+			errs = append(errs, fmt.Errorf("%s: duplicate handle", label))
+		}
+		seen[e.Handle] = true
+		req := e.request()
+		if err := req.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+	}
+	if len(errs) > 0 {
+		return &ExitError{Code: CodeError, Err: errors.Join(errs...)}
+	}
+
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		Handle string `json:"handle"`
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+	results := make([]result, 0, len(entries))
+	failed := 0
+	for i := range entries {
+		e := &entries[i]
+		req := e.request()
+		r := result{Handle: e.Handle, Status: "created"}
+		if err := client.CreateContact(ctx, customer, e.Handle, &req); err != nil {
+			r.Status = "failed"
+			r.Error = err.Error()
+			failed++
+		}
+		results = append(results, r)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	headers := []string{"HANDLE", "STATUS", "ERROR"}
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := r.Status
+		if f.Mode == output.ModeTable {
+			if r.Error != "" {
+				status = f.Colors.Red(status)
+			} else {
+				status = f.Colors.Green(status)
+			}
+		}
+		rows = append(rows, []string{r.Handle, status, r.Error})
+	}
+	if err := f.Output(results, headers, rows); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: CodeAPI, Err: fmt.Errorf("%d of %d contacts failed", failed, len(results))}
+	}
+	return nil
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd())) //nolint:gosec // G115: fd fits in int
+}
+
+// errWizardAborted is returned when input ends before the wizard completes.
+var errWizardAborted = errors.New("input ended before all fields were entered")
+
+// contactWizard prompts for contact fields line by line.
+type contactWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+	all bool
+}
+
+// runContactWizard prompts for the handle and any ContactRequest fields that
+// are missing or invalid, re-asking until each value passes validation. With
+// all set, every field is prompted, using the current value as the default.
+func runContactWizard(in io.Reader, out io.Writer, handle string, req *api.ContactRequest, all bool) (string, error) {
+	w := &contactWizard{in: bufio.NewReader(in), out: out, all: all}
+
+	fmt.Fprintln(out, "Enter contact details. Press Enter to accept [defaults].")
+
+	before := []wizardField{
+		{"Handle", &handle, true, nil},
+		{"Full name", &req.Name, true, nil},
+		{"Organization", &req.Organization, false, nil},
+		{"Email", &req.Email, true, api.ValidateEmail},
+		{"Phone (+CC.NUMBER)", &req.Phone, true, api.ValidatePhone},
+		{"Fax (+CC.NUMBER)", &req.Fax, false, api.ValidatePhone},
+	}
+	after := []wizardField{
+		{"City", &req.City, true, nil},
+		{"State/province", &req.State, false, nil},
+		{"Postal code", &req.PostalCode, true, nil},
+		{"Country (ISO code)", &req.Country, true, api.ValidateCountry},
+	}
+
+	for _, fd := range before {
+		if err := w.field(fd); err != nil {
+			return "", err
+		}
+	}
+	if err := w.address(&req.Address); err != nil {
+		return "", err
+	}
+	for _, fd := range after {
+		if err := w.field(fd); err != nil {
+			return "", err
+		}
+	}
+	req.Country = strings.ToUpper(req.Country)
+
+	return handle, req.Validate()
+}
+
+// wizardField is a single prompted value.
+type wizardField struct {
+	label    string
+	value    *string
+	required bool
+	validate func(string) error
+}
+
+func (fd wizardField) check(v string) error {
+	switch {
+	case v == "" && fd.required:
+		return errors.New("is required")
+	case v == "" || fd.validate == nil:
+		return nil
+	default:
+		return fd.validate(v)
+	}
+}
+
+// field prompts for a single value until it is valid. Fields that already
+// hold a valid value are skipped unless the wizard prompts for everything.
+func (w *contactWizard) field(fd wizardField) error {
+	if !w.all && fd.check(*fd.value) == nil {
+		return nil
+	}
+
+	for {
+		prompt := fd.label
+		if *fd.value != "" {
+			prompt += " [" + *fd.value + "]"
+		}
+		line, err := w.readLine(prompt + ": ")
+		if err != nil {
+			return err
+		}
+		v := *fd.value
+		if line != "" {
+			v = line
+		}
+		if err := fd.check(v); err != nil {
+			fmt.Fprintf(w.out, "  %s %s\n", fd.label, err)
+			continue
+		}
+		*fd.value = v
+		return nil
+	}
+}
+
+// address prompts for address lines until an empty line is entered.
+func (w *contactWizard) address(lines *[]string) error {
+	if !w.all && len(*lines) > 0 {
+		return nil
+	}
+
+	var result []string
+	for i := 0; ; i++ {
+		prompt := fmt.Sprintf("Address line %d", i+1)
+		def := ""
+		if i < len(*lines) {
+			def = (*lines)[i]
+			prompt += " [" + def + "]"
+		}
+		line, err := w.readLine(prompt + ": ")
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			line = def
+		}
+		if line == "" {
+			if len(result) == 0 {
+				fmt.Fprintln(w.out, "  At least one address line is required")
+				continue
+			}
+			break
+		}
+		result = append(result, line)
+	}
+	*lines = result
+	return nil
+}
+
+func (w *contactWizard) readLine(prompt string) (string, error) {
+	fmt.Fprint(w.out, prompt)
+	line, err := w.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		fmt.Fprintln(w.out)
+		return "", errWizardAborted
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// CostCmd is the parent command for cost reporting.
+type CostCmd struct {
+	Forecast CostForecastCmd `cmd:"" help:"Forecast renewal spend from auto-renewing domains"`
+}
+
+// forecastLine is the projected renewal spend for one month, TLD and currency.
+type forecastLine struct {
+	Month    string `json:"month"` // YYYY-MM
+	TLD      string `json:"tld"`
+	Currency string `json:"currency"`
+	Domains  int    `json:"domains"`
+	Amount   int    `json:"amount"` // cents
+}
+
+// renewalForecast is the projected renewal spend over a number of months.
+type renewalForecast struct {
+	From   time.Time      `json:"from"`
+	Until  time.Time      `json:"until"`
+	Lines  []forecastLine `json:"lines"`
+	Totals map[string]int `json:"totals"` // cents per currency
+
+	// Unpriced lists auto-renewing domains whose TLD has no renew price in
+	// the forecast currency.
+	Unpriced []string `json:"unpriced,omitempty"`
+	// Manual counts domains expiring in the window without auto-renew.
+	Manual int `json:"manual"`
+}
+
+// defaultRenewPeriod is used when a domain has no auto-renew period set.
+const defaultRenewPeriod = 12
+
+// forecastRenewals projects the renewals of domains from from until the end of
+// the given number of calendar months, using the pricelist renew prices in
+// currency. A domain whose renewal period is shorter than the window renews
+// repeatedly.
+func forecastRenewals(domains []api.Domain, pricelist *api.Pricelist, currency string, from time.Time, months int) *renewalForecast {
+	until := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()).AddDate(0, months, 0)
+	fc := &renewalForecast{
+		From:   from,
+		Until:  until,
+		Lines:  []forecastLine{},
+		Totals: make(map[string]int),
+	}
+
+	type lineKey struct{ month, tld string }
+	lines := make(map[lineKey]*forecastLine)
+
+	for _, d := range domains {
+		expiry := d.ExpiryDate
+		if expiry.IsZero() || !expiry.Before(until) {
+			continue
+		}
+		if !d.AutoRenew {
+			if !expiry.Before(from) {
+				fc.Manual++
+			}
+			continue
+		}
+
+		period := d.AutoRenewPeriod
+		if period <= 0 {
+			period = defaultRenewPeriod
+		}
+		tld := domainTLD(d.DomainName)
+		perYear, ok := pricelist.PriceIn(tld, api.ActionRenew, currency)
+		if !ok {
+			fc.Unpriced = append(fc.Unpriced, d.DomainName)
+			continue
+		}
+		// Renew prices are per year; periods are in months.
+		amount := perYear * period / 12
+
+		for ; expiry.Before(until); expiry = expiry.AddDate(0, period, 0) {
+			if expiry.Before(from) {
+				continue
+			}
+			key := lineKey{expiry.Format("2006-01"), tld}
+			line, ok := lines[key]
+			if !ok {
+				line = &forecastLine{Month: key.month, TLD: tld, Currency: currency}
+				lines[key] = line
+			}
+			line.Domains++
+			line.Amount += amount
+			fc.Totals[currency] += amount
+		}
+	}
+
+	for _, line := range lines {
+		fc.Lines = append(fc.Lines, *line)
+	}
+	sort.Slice(fc.Lines, func(i, j int) bool {
+		a, b := fc.Lines[i], fc.Lines[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.TLD < b.TLD
+	})
+	sort.Strings(fc.Unpriced)
+	return fc
+}
+
+// CostForecastCmd projects monthly renewal spend.
+type CostForecastCmd struct {
+	Months   int    `help:"Number of calendar months to forecast, starting with the current one" default:"12"`
+	Currency string `help:"Pricelist currency to forecast in (required if the pricelist has several)"`
+	CSV      bool   `help:"Output CSV"`
+}
+
+func (c *CostForecastCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if c.Months < 1 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("--months must be at least 1")}
+	}
+
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	domains, err := client.ListAllDomains(ctx, api.DomainListOptions{})
+	if err != nil {
+		return apiExitError(err)
+	}
+	pricelist, err := client.GetPricelist(ctx, customer)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	currency, err := forecastCurrency(pricelist, c.Currency)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	fc := forecastRenewals(domains, pricelist, currency, time.Now(), c.Months)
+
+	headers := []string{"MONTH", "TLD", "CURRENCY", "DOMAINS", "AMOUNT"}
+	rows := make([][]string, 0, len(fc.Lines))
+	for _, line := range fc.Lines {
+		rows = append(rows, []string{
+			line.Month,
+			line.TLD,
+			line.Currency,
+			fmt.Sprintf("%d", line.Domains),
+			formatCents(line.Amount, ""),
+		})
+	}
+
+	if c.CSV {
+		return output.WriteCSV(os.Stdout, headers, rows)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+	if err := f.Output(fc, headers, rows); err != nil {
+		return err
+	}
+
+	if f.Mode == output.ModeTable {
+		fmt.Printf("\nTotal until %s: %s\n", fc.Until.AddDate(0, 0, -1).Format("2006-01-02"), forecastTotals(fc.Totals))
+		if len(fc.Unpriced) > 0 {
+			fmt.Fprintln(os.Stderr, f.Colors.Yellow(fmt.Sprintf("No %s renew price for %d domain(s): %s",
+				currency, len(fc.Unpriced), strings.Join(fc.Unpriced, ", "))))
+		}
+		if fc.Manual > 0 {
+			fmt.Fprintf(os.Stderr, "%d domain(s) expiring in this period do not auto-renew and are not included.\n", fc.Manual)
+		}
+	}
+	return nil
+}
+
+// forecastCurrency returns the currency to forecast in: the requested one,
+// or the pricelist's only currency. Mixing currencies would price each TLD
+// in whichever currency the pricelist happens to list first.
+func forecastCurrency(pricelist *api.Pricelist, requested string) (string, error) {
+	currencies := pricelist.Currencies()
+	if requested != "" {
+		requested = strings.ToUpper(requested)
+		for _, cur := range currencies {
+			if cur == requested {
+				return cur, nil
+			}
+		}
+		return "", fmt.Errorf("the pricelist has no prices in %s (it has %s)", requested, strings.Join(currencies, ", "))
+	}
+	if len(currencies) <= 1 {
+		return strings.Join(currencies, ""), nil
+	}
+	return "", fmt.Errorf("the pricelist has prices in %s; choose one with --currency", strings.Join(currencies, ", "))
+}
+
+// forecastTotals formats cents per currency as "123.00 EUR + 45.00 USD".
+func forecastTotals(totals map[string]int) string {
+	if len(totals) == 0 {
+		return "0.00"
+	}
+	parts := make([]string, 0, len(totals))
+	for _, cur := range sortedMapKeys(totals) {
+		parts = append(parts, strings.TrimSpace(formatCents(totals[cur], "")+" "+cur))
+	}
+	return strings.Join(parts, " + ")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
+
+func TestForecastRenewals(t *testing.T) {
+	from := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	pricelist := &api.Pricelist{Prices: []api.PricelistEntry{
+		{Product: "domain_com", Action: "RENEW", Currency: "USD", Price: 1000},
+		{Product: "domain_com", Action: "RENEW", Currency: "EUR", Price: 900},
+		{Product: "domain_nl", Action: "RENEW", Currency: "EUR", Price: 800},
+	}}
+	domains := []api.Domain{
+		{DomainName: "a.com", ExpiryDate: date(2026, 4, 1), AutoRenew: true, AutoRenewPeriod: 12},
+		{DomainName: "b.com", ExpiryDate: date(2026, 4, 20), AutoRenew: true},
+		{DomainName: "c.nl", ExpiryDate: date(2026, 5, 1), AutoRenew: true, AutoRenewPeriod: 6},
+		{DomainName: "d.nl", ExpiryDate: date(2026, 6, 1)},                   // no auto-renew
+		{DomainName: "e.io", ExpiryDate: date(2026, 7, 1), AutoRenew: true},  // no price
+		{DomainName: "f.com", ExpiryDate: date(2026, 3, 1), AutoRenew: true}, // already renewed
+		{DomainName: "g.com", ExpiryDate: date(2027, 3, 1), AutoRenew: true}, // after window
+	}
+
+	fc := forecastRenewals(domains, pricelist, "EUR", from, 12)
+
+	if !fc.Until.Equal(date(2027, 3, 1)) {
+		t.Errorf("Until = %v, want 2027-03-01", fc.Until)
+	}
+	want := []forecastLine{
+		{Month: "2026-04", TLD: "com", Currency: "EUR", Domains: 2, Amount: 1800},
+		{Month: "2026-05", TLD: "nl", Currency: "EUR", Domains: 1, Amount: 400},
+		{Month: "2026-11", TLD: "nl", Currency: "EUR", Domains: 1, Amount: 400},
+	}
+	if len(fc.Lines) != len(want) {
+		t.Fatalf("Lines = %+v, want %+v", fc.Lines, want)
+	}
+	for i := range want {
+		if fc.Lines[i] != want[i] {
+			t.Errorf("Lines[%d] = %+v, want %+v", i, fc.Lines[i], want[i])
+		}
+	}
+	if len(fc.Totals) != 1 || fc.Totals["EUR"] != 2600 {
+		t.Errorf("Totals = %v", fc.Totals)
+	}
+	if len(fc.Unpriced) != 1 || fc.Unpriced[0] != "e.io" {
+		t.Errorf("Unpriced = %v, want [e.io]", fc.Unpriced)
+	}
+	if fc.Manual != 1 {
+		t.Errorf("Manual = %d, want 1", fc.Manual)
+	}
+	if got := forecastTotals(fc.Totals); got != "26.00 EUR" {
+		t.Errorf("forecastTotals() = %q", got)
+	}
+
+	// .nl has no USD price, so it is unpriced rather than mixed in.
+	fc = forecastRenewals(domains, pricelist, "USD", from, 12)
+	if got := forecastTotals(fc.Totals); got != "20.00 USD" {
+		t.Errorf("USD forecastTotals() = %q", got)
+	}
+	if got := strings.Join(fc.Unpriced, ","); got != "c.nl,e.io" {
+		t.Errorf("USD Unpriced = %q, want c.nl,e.io", got)
+	}
+
+	if _, err := forecastCurrency(pricelist, ""); err == nil {
+		t.Error("forecastCurrency() = nil error for a pricelist in EUR and USD")
+	}
+	if cur, err := forecastCurrency(pricelist, "usd"); err != nil || cur != "USD" {
+		t.Errorf("forecastCurrency(usd) = %q, %v", cur, err)
+	}
+	if _, err := forecastCurrency(pricelist, "GBP"); err == nil {
+		t.Error("forecastCurrency(GBP) = nil error")
+	}
+	single := &api.Pricelist{Prices: pricelist.Prices[:1]}
+	if cur, err := forecastCurrency(single, ""); err != nil || cur != "USD" {
+		t.Errorf("forecastCurrency(single) = %q, %v", cur, err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
-	"github.com/dedene/realtime-register-cli/internal/auth"
 	"github.com/dedene/realtime-register-cli/internal/config"
 	"github.com/dedene/realtime-register-cli/internal/output"
 )
@@ -18,7 +17,10 @@ type DomainCmd struct {
 	Get            DomainGetCmd            `cmd:"" help:"Get domain details"`
 	Check          DomainCheckCmd          `cmd:"" help:"Check domain availability"`
 	CheckBulk      DomainCheckBulkCmd      `cmd:"" name:"check-bulk" help:"Bulk check availability (IsProxy)"`
+	CheckNS        DomainCheckNSCmd        `cmd:"" name:"check-ns" help:"Check nameserver delegation health"`
 	Register       DomainRegisterCmd       `cmd:"" help:"Register a domain"`
+	RegisterBulk   DomainRegisterBulkCmd   `cmd:"" name:"register-bulk" help:"Register domains from a manifest"`
+	Preflight      DomainPreflightCmd      `cmd:"" help:"Check that a registration would succeed"`
 	Update         DomainUpdateCmd         `cmd:"" help:"Update domain settings"`
 	Delete         DomainDeleteCmd         `cmd:"" help:"Delete a domain"`
 	Renew          DomainRenewCmd          `cmd:"" help:"Renew a domain"`
@@ -36,16 +38,7 @@ type DomainListCmd struct {
 	Offset         int    `help:"Offset for pagination"`
 }
 
-func (c *DomainListCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
-
+func (c *DomainListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	opts := api.DomainListOptions{
 		ListOptions: api.ListOptions{
 			Limit:  c.Limit,
@@ -59,7 +52,7 @@ func (c *DomainListCmd) Run(flags *RootFlags) error {
 
 	resp, err := client.ListDomains(ctx, opts)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -89,18 +82,10 @@ type DomainGetCmd struct {
 	Domain string `arg:"" help:"Domain name"`
 }
 
-func (c *DomainGetCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *DomainGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	domain, err := client.GetDomain(ctx, c.Domain)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -127,14 +112,7 @@ type DomainCheckCmd struct {
 	TLDs   []string `help:"Check multiple TLDs (provide name without TLD)" short:"t"`
 }
 
-func (c *DomainCheckCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *DomainCheckCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	tlds := c.TLDs
 	if len(tlds) == 0 {
 		cfg, _ := config.ReadConfig()
@@ -143,7 +121,6 @@ func (c *DomainCheckCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
 
 	if len(tlds) > 0 {
@@ -158,7 +135,7 @@ func (c *DomainCheckCmd) Run(flags *RootFlags) error {
 			domain := name + "." + strings.TrimPrefix(tld, ".")
 			result, err := client.CheckDomain(ctx, domain)
 			if err != nil {
-				return &ExitError{Code: CodeAPI, Err: err}
+				return apiExitError(err)
 			}
 			results = append(results, *result)
 		}
@@ -184,19 +161,18 @@ func (c *DomainCheckCmd) Run(flags *RootFlags) error {
 
 	result, err := client.CheckDomain(ctx, c.Domain)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	// Fetch pricing if available and customer configured
-	var currency string
+	currency := result.Currency
 	var noCustomer bool
 	if result.Available && result.Price == 0 {
 		cfg, _ := config.ReadConfig()
 		if cfg == nil || cfg.Customer == "" {
 			noCustomer = true
 		} else {
-			if idx := strings.LastIndex(c.Domain, "."); idx > 0 {
-				tld := c.Domain[idx+1:]
+			if tld := domainTLD(c.Domain); tld != c.Domain {
 				if pricelist, err := client.GetPricelist(ctx, cfg.Customer); err == nil {
 					if cents, cur, ok := pricelist.GetTLDPrice(tld); ok {
 						result.Price = float64(cents) / 100
@@ -247,10 +223,11 @@ func (c *DomainCheckCmd) Run(flags *RootFlags) error {
 
 // DomainCheckBulkCmd checks multiple domains via IsProxy.
 type DomainCheckBulkCmd struct {
-	Domains []string `arg:"" help:"Domain names to check" required:""`
+	Domains     []string `arg:"" help:"Domain names to check" required:""`
+	Connections int      `help:"Number of IsProxy connections to check over in parallel" default:"4"`
 }
 
-func (c *DomainCheckBulkCmd) Run(flags *RootFlags) error {
+func (c *DomainCheckBulkCmd) Run(ctx context.Context, flags *RootFlags) error {
 	apiKey, err := getAPIKey()
 	if err != nil {
 		return err
@@ -260,15 +237,16 @@ func (c *DomainCheckBulkCmd) Run(flags *RootFlags) error {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("maximum 50 domains per request")}
 	}
 
-	client := api.NewIsProxyClient(apiKey)
-	if err := client.Connect(); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+	if c.Connections < 1 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("--connections must be at least 1")}
 	}
-	defer func() { _ = client.Close() }()
 
-	results, err := client.CheckMany(c.Domains)
+	pool := api.NewIsProxyPool(apiKey, c.Connections)
+	defer func() { _ = pool.Close() }()
+
+	results, err := pool.CheckMany(ctx, c.Domains)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -296,44 +274,76 @@ func (c *DomainCheckBulkCmd) Run(flags *RootFlags) error {
 
 // DomainRegisterCmd registers a domain.
 type DomainRegisterCmd struct {
-	Domain     string   `arg:"" help:"Domain name to register"`
-	Registrant string   `help:"Registrant contact handle" required:""`
-	Period     int      `help:"Registration period in years" default:"1"`
-	NS         []string `help:"Nameservers (comma-separated)"`
-	AutoRenew  bool     `help:"Enable auto-renewal"`
-	Privacy    bool     `help:"Enable privacy proxy"`
+	Domain string `arg:"" help:"Domain name to register"`
+	RegisterFlags
+	AcceptPremiumPrice float64 `help:"Confirm the total premium price for the period (required for premium domains)"`
+	Check              bool    `help:"Only run preflight checks; do not register"`
 }
 
-func (c *DomainRegisterCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
+func (c *DomainRegisterCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+	req := c.request(c.Domain, cfg)
+
+	if c.Check {
+		return runPreflightCmd(ctx, flags, client, c.Domain, req)
+	}
 
-	apiKey, err := getAPIKey()
+	if req.Registrant == "" {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("registrant is required; use --registrant or: rr config set register.registrant <handle>")}
+	}
+
+	// Property checks and pricelist prices need a customer; continue without one.
+	customer, _ := getCustomer()
+
+	// Catch missing registry-specific contact properties before the registry does.
+	if customer != "" {
+		handles := []string{req.Registrant, req.Admin, req.Tech, req.Billing}
+		if tld, err := client.GetTLD(ctx, domainTLD(c.Domain)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not check contact properties: %v\n", err)
+		} else if err := checkRegistrationProperties(ctx, client, customer, tld, handles); err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+	}
+
+	quote, err := quoteRegistration(ctx, client, customer, c.Domain, req.Period, nil)
 	if err != nil {
-		return err
+		return apiExitError(err)
+	}
+	if err := checkPremiumAcceptance(c.Domain, quote, c.AcceptPremiumPrice); err != nil {
+		return &ExitError{Code: CodeError, Err: err}
 	}
 
 	if !flags.Yes {
-		fmt.Printf("Register %s for %d year(s)? [y/N]: ", c.Domain, c.Period)
+		price := quote.String()
+		if quote.Premium {
+			price = "premium price " + price
+		}
+		fmt.Printf("Register %s for %d year(s) at %s? [y/N]: ", c.Domain, req.Period, price)
 		var response string
 		fmt.Scanln(&response)
 		if response != "y" && response != "Y" {
 			fmt.Fprintln(os.Stderr, "Cancelled.")
 			return nil
 		}
-	}
 
-	client := api.NewClient(apiKey)
-	req := api.RegisterRequest{
-		Period:       c.Period,
-		Registrant:   c.Registrant,
-		Nameservers:  c.NS,
-		AutoRenew:    &c.AutoRenew,
-		PrivacyProxy: &c.Privacy,
+		// The price may have moved while the prompt was open, so bypass
+		// the pricelist cache.
+		current, err := quoteRegistration(ctx, client.Refreshed(), customer, c.Domain, req.Period, nil)
+		if err != nil {
+			return apiExitError(err)
+		}
+		if current.Premium != quote.Premium || !samePrice(current.Total, quote.Total) || current.Currency != quote.Currency {
+			return &ExitError{Code: CodeError, Err: fmt.Errorf("price for %s changed from %s to %s; not registering", c.Domain, quote, current)}
+		}
 	}
+	req.Billables = premiumBillables(c.Domain, req.Period, quote)
 
-	process, err := client.RegisterDomain(ctx, c.Domain, &req)
+	process, err := client.RegisterDomain(ctx, c.Domain, req)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -347,6 +357,72 @@ func (c *DomainRegisterCmd) Run(flags *RootFlags) error {
 	return f.OutputSingle(process, kvPairs)
 }
 
+// RegisterFlags are the registration settings shared by register and
+// preflight. Unset flags fall back to the register defaults in config.
+type RegisterFlags struct {
+	Registrant string   `help:"Registrant contact handle"`
+	Admin      string   `help:"Admin contact handle"`
+	Tech       string   `help:"Tech contact handle"`
+	Billing    string   `help:"Billing contact handle"`
+	Period     int      `help:"Registration period in years (default 1)"`
+	NS         []string `help:"Nameservers (comma-separated)"`
+	AutoRenew  *bool    `help:"Enable auto-renewal" negatable:""`
+	Privacy    *bool    `help:"Enable privacy proxy" negatable:""`
+}
+
+// request builds a RegisterRequest for domain. Each setting is taken from
+// the flags, then the config's per-TLD register defaults, then the
+// top-level register defaults. Auto-renew finally falls back to the global
+// auto_renew setting, and the period to one year.
+func (r *RegisterFlags) request(domain string, cfg *config.File) *api.RegisterRequest {
+	var d config.RegisterDefaults
+	if cfg != nil {
+		d = cfg.Register.ForTLD(domainTLD(domain))
+		if d.AutoRenew == nil {
+			d.AutoRenew = cfg.AutoRenew
+		}
+	}
+
+	req := &api.RegisterRequest{
+		Period:       firstNonZero(r.Period, d.Period, 1),
+		Registrant:   firstNonEmpty(r.Registrant, d.Registrant),
+		Admin:        firstNonEmpty(r.Admin, d.Admin),
+		Tech:         firstNonEmpty(r.Tech, d.Tech),
+		Billing:      firstNonEmpty(r.Billing, d.Billing),
+		Nameservers:  r.NS,
+		AutoRenew:    r.AutoRenew,
+		PrivacyProxy: r.Privacy,
+	}
+	if len(req.Nameservers) == 0 {
+		req.Nameservers = d.Nameservers
+	}
+	if req.AutoRenew == nil {
+		req.AutoRenew = d.AutoRenew
+	}
+	if req.PrivacyProxy == nil {
+		req.PrivacyProxy = d.Privacy
+	}
+	return req
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNonZero(values ...int) int {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
+
 // DomainUpdateCmd updates domain settings.
 type DomainUpdateCmd struct {
 	Domain     string   `arg:"" help:"Domain name"`
@@ -355,15 +431,7 @@ type DomainUpdateCmd struct {
 	AutoRenew  *bool    `help:"Enable/disable auto-renewal"`
 }
 
-func (c *DomainUpdateCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *DomainUpdateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	req := api.UpdateRequest{
 		Registrant:  c.Registrant,
 		Nameservers: c.NS,
@@ -371,7 +439,7 @@ func (c *DomainUpdateCmd) Run(_ *RootFlags) error {
 	}
 
 	if err := client.UpdateDomain(ctx, c.Domain, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Domain %s updated.\n", c.Domain)
@@ -383,14 +451,7 @@ type DomainDeleteCmd struct {
 	Domain string `arg:"" help:"Domain name to delete"`
 }
 
-func (c *DomainDeleteCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *DomainDeleteCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if !flags.Yes {
 		fmt.Printf("Delete domain %s? This cannot be undone. [y/N]: ", c.Domain)
 		var response string
@@ -401,9 +462,8 @@ func (c *DomainDeleteCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	if err := client.DeleteDomain(ctx, c.Domain); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Domain %s deleted.\n", c.Domain)
@@ -416,14 +476,7 @@ type DomainRenewCmd struct {
 	Period int    `help:"Renewal period in years" default:"1"`
 }
 
-func (c *DomainRenewCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *DomainRenewCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if !flags.Yes {
 		fmt.Printf("Renew %s for %d year(s)? [y/N]: ", c.Domain, c.Period)
 		var response string
@@ -434,10 +487,9 @@ func (c *DomainRenewCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	process, err := client.RenewDomain(ctx, c.Domain, c.Period)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -459,14 +511,7 @@ type DomainTransferInCmd struct {
 	AutoRenew  bool   `help:"Enable auto-renewal"`
 }
 
-func (c *DomainTransferInCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *DomainTransferInCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if !flags.Yes {
 		fmt.Printf("Transfer %s? [y/N]: ", c.Domain)
 		var response string
@@ -477,7 +522,6 @@ func (c *DomainTransferInCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	req := api.TransferRequest{
 		AuthCode:   c.AuthCode,
 		Registrant: c.Registrant,
@@ -486,7 +530,7 @@ func (c *DomainTransferInCmd) Run(flags *RootFlags) error {
 
 	process, err := client.TransferDomain(ctx, c.Domain, req)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -505,18 +549,10 @@ type DomainTransferStatusCmd struct {
 	Domain string `arg:"" help:"Domain name"`
 }
 
-func (c *DomainTransferStatusCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *DomainTransferStatusCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	domain, err := client.GetDomain(ctx, c.Domain)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -529,19 +565,6 @@ func (c *DomainTransferStatusCmd) Run(flags *RootFlags) error {
 	return f.OutputSingle(domain, kvPairs)
 }
 
-// getAPIKey retrieves the API key from env or keyring.
-func getAPIKey() (string, error) {
-	store, err := auth.NewStore("")
-	if err != nil {
-		return "", &ExitError{Code: CodeAuth, Err: fmt.Errorf("not authenticated: %w", err)}
-	}
-	key, err := store.GetAPIKey()
-	if err != nil {
-		return "", &ExitError{Code: CodeAuth, Err: fmt.Errorf("not authenticated: %w", err)}
-	}
-	return key, nil
-}
-
 // getCustomer retrieves customer from RR_CUSTOMER env or config.
 func getCustomer() (string, error) {
 	if customer := os.Getenv("RR_CUSTOMER"); customer != "" {
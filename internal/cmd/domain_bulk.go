@@ -0,0 +1,441 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/config"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// bulkManifest is the register-bulk file format. Entries inherit any field
+// they leave unset from Defaults.
+type bulkManifest struct {
+	Defaults bulkEntry   `yaml:"defaults"`
+	Domains  []bulkEntry `yaml:"domains"`
+}
+
+// bulkEntry describes one domain registration in a manifest.
+type bulkEntry struct {
+	Domain    string       `yaml:"domain"`
+	Period    int          `yaml:"period"`
+	Contacts  bulkContacts `yaml:"contacts"`
+	NS        []string     `yaml:"ns"`
+	Privacy   *bool        `yaml:"privacy"`
+	AutoRenew *bool        `yaml:"autoRenew"`
+
+	// AcceptPremiumPrice confirms the total price of a premium domain.
+	AcceptPremiumPrice float64 `yaml:"acceptPremiumPrice"`
+
+	// req is the resolved registration, including config defaults.
+	req *api.RegisterRequest
+}
+
+// bulkContacts holds the contact handle for each role.
+type bulkContacts struct {
+	Registrant string `yaml:"registrant"`
+	Admin      string `yaml:"admin"`
+	Tech       string `yaml:"tech"`
+	Billing    string `yaml:"billing"`
+}
+
+// withDefaults returns e with unset fields taken from d.
+func (e bulkEntry) withDefaults(d bulkEntry) bulkEntry {
+	if e.Period == 0 {
+		e.Period = d.Period
+	}
+	if e.Contacts.Registrant == "" {
+		e.Contacts.Registrant = d.Contacts.Registrant
+	}
+	if e.Contacts.Admin == "" {
+		e.Contacts.Admin = d.Contacts.Admin
+	}
+	if e.Contacts.Tech == "" {
+		e.Contacts.Tech = d.Contacts.Tech
+	}
+	if e.Contacts.Billing == "" {
+		e.Contacts.Billing = d.Contacts.Billing
+	}
+	if len(e.NS) == 0 {
+		e.NS = d.NS
+	}
+	if e.Privacy == nil {
+		e.Privacy = d.Privacy
+	}
+	if e.AutoRenew == nil {
+		e.AutoRenew = d.AutoRenew
+	}
+	return e
+}
+
+// resolve fills in e.req, falling back to the config register defaults
+// for anything neither the entry nor the manifest defaults set.
+func (e *bulkEntry) resolve(cfg *config.File) {
+	flags := RegisterFlags{
+		Registrant: e.Contacts.Registrant,
+		Admin:      e.Contacts.Admin,
+		Tech:       e.Contacts.Tech,
+		Billing:    e.Contacts.Billing,
+		Period:     e.Period,
+		NS:         e.NS,
+		AutoRenew:  e.AutoRenew,
+		Privacy:    e.Privacy,
+	}
+	e.req = flags.request(e.Domain, cfg)
+}
+
+// loadBulkManifest reads a manifest and resolves every entry against the
+// manifest defaults and then cfg.
+func loadBulkManifest(path string, cfg *config.File) ([]bulkEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is user-provided CLI arg
+	if err != nil {
+		return nil, err
+	}
+
+	var m bulkManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(m.Domains) == 0 {
+		return nil, fmt.Errorf("%s: no domains listed", path)
+	}
+
+	entries := make([]bulkEntry, 0, len(m.Domains))
+	seen := make(map[string]bool, len(m.Domains))
+	for i, e := range m.Domains {
+		e.Domain = strings.ToLower(strings.TrimSpace(e.Domain))
+		if e.Domain == "" {
+			return nil, fmt.Errorf("%s: entry %d has no domain", path, i+1)
+		}
+		if seen[e.Domain] {
+			return nil, fmt.Errorf("%s: %s is listed twice", path, e.Domain)
+		}
+		seen[e.Domain] = true
+		e = e.withDefaults(m.Defaults)
+		e.resolve(cfg)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// bulkCost is the price of one manifest entry.
+type bulkCost struct {
+	Domain   string  `json:"domain"`
+	Period   int     `json:"period"`
+	Premium  bool    `json:"premium"`
+	Price    float64 `json:"price"` // total for the period; 0 if unknown
+	Currency string  `json:"currency,omitempty"`
+
+	// PricelistCurrency is set when a premium price is quoted in another
+	// currency than the TLD's pricelist, which the account is billed in.
+	PricelistCurrency string `json:"pricelistCurrency,omitempty"`
+}
+
+// currencyMismatch reports whether the price is not in the pricelist currency.
+func (c *bulkCost) currencyMismatch() bool {
+	return c.PricelistCurrency != ""
+}
+
+// estimateBulkCost prices each entry: premium names use the availability
+// price and currency, others the pricelist CREATE price, both multiplied by
+// the period.
+func estimateBulkCost(entries []bulkEntry, reports []*preflightReport, pricelist *api.Pricelist) []bulkCost {
+	costs := make([]bulkCost, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		c := bulkCost{Domain: e.Domain, Period: e.req.Period}
+
+		var perYear float64
+		var listCurrency string
+		if pricelist != nil {
+			if cents, cur, ok := pricelist.GetTLDPrice(domainTLD(e.Domain)); ok {
+				perYear = float64(cents) / 100
+				c.Currency, listCurrency = cur, cur
+			}
+		}
+		if avail := reports[i].Availability; avail != nil && avail.Premium {
+			c.Premium = true
+			perYear = avail.Price
+			c.Currency = avail.Currency
+			if listCurrency != "" && !strings.EqualFold(c.Currency, listCurrency) {
+				c.PricelistCurrency = listCurrency
+			}
+		}
+		c.Price = perYear * float64(c.Period)
+		costs[i] = c
+	}
+	return costs
+}
+
+// costTotals sums costs per currency, formatted as "123.00 EUR + 45.00 USD".
+// Prices quoted without a currency are totalled separately.
+func costTotals(costs []bulkCost) string {
+	totals := make(map[string]float64)
+	unknown := 0
+	for _, c := range costs {
+		if c.Price == 0 {
+			unknown++
+			continue
+		}
+		totals[c.Currency] += c.Price
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for cur := range totals {
+		currencies = append(currencies, cur)
+	}
+	sort.Strings(currencies)
+
+	parts := make([]string, 0, len(currencies)+1)
+	for _, cur := range currencies {
+		label := cur
+		if label == "" {
+			label = "(unknown currency)"
+		}
+		parts = append(parts, fmt.Sprintf("%.2f %s", totals[cur], label))
+	}
+	if unknown > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpriced", unknown))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// bulkResult is the outcome of one registration.
+type bulkResult struct {
+	Domain    string `json:"domain"`
+	ProcessID int    `json:"processId,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DomainRegisterBulkCmd registers many domains from a manifest.
+type DomainRegisterBulkCmd struct {
+	Manifest    string        `arg:"" help:"YAML manifest listing domains to register" type:"existingfile"`
+	Parallel    int           `help:"Maximum concurrent registrations" default:"4"`
+	Wait        bool          `help:"Wait for registration processes to finish"`
+	WaitTimeout time.Duration `help:"Maximum time to wait for processes" default:"10m"`
+}
+
+func (c *DomainRegisterBulkCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if c.Parallel < 1 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("--parallel must be at least 1")}
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	entries, err := loadBulkManifest(c.Manifest, cfg)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	fmt.Fprintf(os.Stderr, "Checking %d domain(s)...\n", len(entries))
+	reports, err := c.preflight(ctx, client, customer, entries)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	var failed []string
+	for _, r := range reports {
+		for _, chk := range r.Checks {
+			if chk.Status == checkFail {
+				failed = append(failed, fmt.Sprintf("%s: %s: %s", r.Domain, chk.Check, chk.Detail))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("preflight failed, nothing registered:\n  %s", strings.Join(failed, "\n  "))}
+	}
+
+	pricelist, err := client.GetPricelist(ctx, customer)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not fetch pricelist: %v\n", err)
+	}
+	costs := estimateBulkCost(entries, reports, pricelist)
+
+	// Premium names need their price accepted in the manifest.
+	var premiumErrs []error
+	for i := range entries {
+		e, cost := &entries[i], &costs[i]
+		q := &registrationQuote{Premium: cost.Premium, Total: cost.Price, Currency: cost.Currency}
+		if err := checkPremiumAcceptance(e.Domain, q, e.AcceptPremiumPrice); err != nil {
+			premiumErrs = append(premiumErrs, err)
+			continue
+		}
+		e.req.Billables = premiumBillables(e.Domain, e.req.Period, q)
+	}
+	if len(premiumErrs) > 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("%w\n(set acceptPremiumPrice on the manifest entry)", errors.Join(premiumErrs...))}
+	}
+
+	if f.Mode == output.ModeTable {
+		if err := renderBulkCosts(f, costs); err != nil {
+			return err
+		}
+	}
+	for _, cost := range costs {
+		if cost.currencyMismatch() {
+			fmt.Fprintf(os.Stderr, "warning: %s premium price is in %s, not the pricelist currency %s; totals are kept per currency\n",
+				cost.Domain, firstNonEmpty(cost.Currency, "an unknown currency"), cost.PricelistCurrency)
+		}
+	}
+
+	if !flags.Yes {
+		fmt.Fprintf(os.Stderr, "\nRegister %d domain(s) for %s? [y/N]: ", len(entries), costTotals(costs))
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	results := c.register(ctx, client, entries)
+
+	if c.Wait {
+		c.wait(ctx, client, results)
+	}
+
+	headers := []string{"DOMAIN", "PROCESS", "STATUS", "ERROR"}
+	rows := make([][]string, 0, len(results))
+	errCount := 0
+	for _, r := range results {
+		status := r.Status
+		if r.Error != "" {
+			errCount++
+			if f.Mode == output.ModeTable {
+				status = f.Colors.Red(status)
+			}
+		}
+		process := ""
+		if r.ProcessID != 0 {
+			process = fmt.Sprintf("%d", r.ProcessID)
+		}
+		rows = append(rows, []string{r.Domain, process, status, r.Error})
+	}
+	if err := f.Output(results, headers, rows); err != nil {
+		return err
+	}
+
+	if errCount > 0 {
+		return &ExitError{Code: CodeAPI, Err: fmt.Errorf("%d of %d registrations failed", errCount, len(results))}
+	}
+	return nil
+}
+
+// preflight checks all entries with at most c.Parallel requests in flight.
+func (c *DomainRegisterBulkCmd) preflight(ctx context.Context, client *api.Client, customer string, entries []bulkEntry) ([]*preflightReport, error) {
+	p := newPreflighter(client, customer)
+	reports := make([]*preflightReport, len(entries))
+	errs := make([]error, len(entries))
+
+	err := forEachParallel(ctx, len(entries), c.Parallel, func(ctx context.Context, i int) {
+		reports[i], errs[i] = p.run(ctx, entries[i].Domain, entries[i].req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reports, errors.Join(errs...)
+}
+
+// register submits every entry with at most c.Parallel requests in flight.
+func (c *DomainRegisterBulkCmd) register(ctx context.Context, client *api.Client, entries []bulkEntry) []bulkResult {
+	results := make([]bulkResult, len(entries))
+
+	err := forEachParallel(ctx, len(entries), c.Parallel, func(ctx context.Context, i int) {
+		e, r := &entries[i], &results[i]
+		r.Domain = e.Domain
+		process, err := client.RegisterDomain(ctx, e.Domain, e.req)
+		if err != nil {
+			r.Status = "failed"
+			r.Error = err.Error()
+		} else {
+			r.ProcessID = process.ID
+			r.Status = process.Status
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s\n", e.Domain, r.Status)
+	})
+	if err != nil {
+		// Entries never handed to a worker were not submitted.
+		for i := range results {
+			if results[i].Domain == "" {
+				results[i] = bulkResult{Domain: entries[i].Domain, Status: "skipped", Error: err.Error()}
+			}
+		}
+	}
+
+	return results
+}
+
+// wait polls each submitted process until it finishes or the timeout expires.
+func (c *DomainRegisterBulkCmd) wait(ctx context.Context, client *api.Client, results []bulkResult) {
+	ctx, cancel := context.WithTimeout(ctx, c.WaitTimeout)
+	defer cancel()
+
+	fmt.Fprintln(os.Stderr, "Waiting for processes to finish...")
+
+	var pending []*bulkResult
+	for i := range results {
+		if results[i].ProcessID != 0 {
+			pending = append(pending, &results[i])
+		}
+	}
+
+	_ = forEachParallel(ctx, len(pending), c.Parallel, func(ctx context.Context, i int) {
+		r := pending[i]
+		process, err := waitForProcess(ctx, client, r.ProcessID, 5*time.Second)
+		if process != nil {
+			r.Status = process.Status
+			if strings.EqualFold(process.Status, "failed") || strings.EqualFold(process.Status, "cancelled") {
+				r.Error = process.Message
+				if r.Error == "" {
+					r.Error = process.StatusDetail
+				}
+				if r.Error == "" {
+					r.Error = "process " + strings.ToLower(process.Status)
+				}
+			}
+		}
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			r.Error = err.Error()
+		}
+	})
+}
+
+func renderBulkCosts(f *output.Formatter, costs []bulkCost) error {
+	headers := []string{"DOMAIN", "PERIOD", "PRICE", "NOTE"}
+	rows := make([][]string, 0, len(costs))
+	for _, c := range costs {
+		price := "unknown"
+		if c.Price > 0 {
+			price = strings.TrimSpace(fmt.Sprintf("%.2f %s", c.Price, c.Currency))
+		}
+		note := ""
+		if c.Premium {
+			note = "premium"
+			if c.currencyMismatch() {
+				note += fmt.Sprintf(", not in pricelist currency %s", c.PricelistCurrency)
+			}
+			note = f.Colors.Yellow(note)
+		}
+		rows = append(rows, []string{c.Domain, fmt.Sprintf("%dy", c.Period), price, note})
+	}
+	return f.Output(costs, headers, rows)
+}
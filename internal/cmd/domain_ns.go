@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/dnscheck"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// DomainCheckNSCmd checks nameserver delegation health for a domain.
+type DomainCheckNSCmd struct {
+	Domain       string        `arg:"" help:"Domain name"`
+	NS           []string      `help:"Nameservers to check instead of the registered ones"`
+	QueryTimeout time.Duration `help:"Timeout for each DNS query" default:"5s"`
+}
+
+func (c *DomainCheckNSCmd) Run(ctx context.Context, flags *RootFlags) error {
+	nameservers := c.NS
+	if len(nameservers) == 0 {
+		// Only look up the registered nameservers when none were given, so
+		// --ns works without an API key.
+		client, err := newAPIClient(flags)
+		if err != nil {
+			return err
+		}
+		domain, err := client.GetDomain(ctx, c.Domain)
+		if err != nil {
+			return apiExitError(err)
+		}
+		nameservers = domain.NameServers
+	}
+
+	dc := dnscheck.NewClient()
+	dc.Timeout = c.QueryTimeout
+
+	report, err := dc.CheckDelegation(ctx, c.Domain, nameservers)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if flags.JSON {
+		if err := f.Output(report, nil, nil); err != nil {
+			return err
+		}
+	} else if err := renderDelegationReport(f, report); err != nil {
+		return err
+	}
+
+	if !report.Healthy() {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("%s: %d delegation issue(s) found", report.Domain, len(report.Issues))}
+	}
+	return nil
+}
+
+func renderDelegationReport(f *output.Formatter, report *dnscheck.DelegationReport) error {
+	headers := []string{"NAMESERVER", "ADDRESS", "AUTHORITATIVE", "SERIAL", "STATUS"}
+	rows := make([][]string, 0, len(report.Servers))
+	for _, s := range report.Servers {
+		addr := ""
+		if len(s.Addresses) > 0 {
+			addr = s.Addresses[0]
+		}
+		aa := "no"
+		if s.Authoritative {
+			aa = "yes"
+		}
+		serial := ""
+		if s.Serial > 0 {
+			serial = fmt.Sprintf("%d", s.Serial)
+		}
+		status := "ok"
+		if s.Lame {
+			status = "lame: " + s.Error
+		}
+		if f.Mode == output.ModeTable {
+			if s.Lame {
+				status = f.Colors.Red(status)
+			} else {
+				status = f.Colors.Green(status)
+			}
+		}
+		rows = append(rows, []string{s.Name, addr, aa, serial, status})
+	}
+
+	if err := f.Output(report.Servers, headers, rows); err != nil {
+		return err
+	}
+	if f.Mode != output.ModeTable {
+		return nil
+	}
+
+	fmt.Println()
+	kvPairs := [][2]string{
+		{"Registered NS", strings.Join(report.Registered, ", ")},
+		{"Parent NS", strings.Join(report.Parent, ", ")},
+		{"Child NS", strings.Join(report.Child, ", ")},
+	}
+	if err := output.WriteKV(os.Stdout, kvPairs, f.Colors); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if report.Healthy() {
+		fmt.Println(f.Colors.Green("Delegation is healthy."))
+		return nil
+	}
+	fmt.Println("Issues:")
+	for _, issue := range report.Issues {
+		fmt.Printf("  - %s\n", f.Colors.Yellow(issue))
+	}
+	return nil
+}
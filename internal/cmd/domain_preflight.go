@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/config"
+	"github.com/dedene/realtime-register-cli/internal/dnscheck"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// Preflight check results.
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// preflightCheck is the outcome of one registration precondition.
+type preflightCheck struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// preflightReport collects every check for one domain registration.
+type preflightReport struct {
+	Domain string           `json:"domain"`
+	Checks []preflightCheck `json:"checks"`
+
+	// Availability is the check result, kept for callers that need the price.
+	Availability *api.DomainAvailability `json:"-"`
+}
+
+// OK returns true if no check failed. Warnings do not block registration.
+func (r *preflightReport) OK() bool {
+	return r.failures() == 0
+}
+
+func (r *preflightReport) failures() int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Status == checkFail {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *preflightReport) add(check, status, detail string) {
+	r.Checks = append(r.Checks, preflightCheck{Check: check, Status: status, Detail: detail})
+}
+
+// preflighter runs registration checks against the API and DNS.
+type preflighter struct {
+	client   *api.Client
+	customer string
+	dns      *dnscheck.Client
+
+	// contacts caches looked-up contacts by handle across domains.
+	mu       sync.Mutex
+	contacts map[string]*api.Contact
+}
+
+func newPreflighter(client *api.Client, customer string) *preflighter {
+	return &preflighter{
+		client:   client,
+		customer: customer,
+		dns:      dnscheck.NewClient(),
+		contacts: make(map[string]*api.Contact),
+	}
+}
+
+// run checks every precondition for registering domain with req and
+// reports all problems rather than stopping at the first. API errors
+// unrelated to the domain itself (e.g. authentication) are returned.
+func (p *preflighter) run(ctx context.Context, domain string, req *api.RegisterRequest) (*preflightReport, error) {
+	report := &preflightReport{Domain: domain, Checks: []preflightCheck{}}
+
+	avail, err := p.client.CheckDomain(ctx, domain)
+	switch {
+	case err != nil:
+		if !isDomainError(err) {
+			return nil, err
+		}
+		report.add("availability", checkFail, err.Error())
+	case !avail.Available:
+		report.add("availability", checkFail, "domain is not available")
+	default:
+		report.Availability = avail
+		report.add("availability", checkOK, "available")
+	}
+
+	if avail != nil && avail.Premium {
+		detail := "premium domain"
+		if avail.Price > 0 {
+			price := strings.TrimSpace(fmt.Sprintf("%.2f %s", avail.Price, avail.Currency))
+			detail = fmt.Sprintf("premium domain, %s/year", price)
+		}
+		report.add("premium", checkWarn, detail)
+	}
+
+	tld, err := p.client.GetTLD(ctx, domainTLD(domain))
+	if err != nil {
+		if !isDomainError(err) {
+			return nil, err
+		}
+		report.add("tld", checkFail, err.Error())
+	} else {
+		p.checkPeriod(report, tld, req.Period)
+	}
+
+	if err := p.checkContacts(ctx, report, tld, req); err != nil {
+		return nil, err
+	}
+
+	p.checkNameservers(ctx, report, req.Nameservers)
+
+	return report, nil
+}
+
+func (p *preflighter) checkPeriod(report *preflightReport, tld *api.TLDInfo, period int) {
+	switch {
+	case tld.MinPeriod > 0 && period < tld.MinPeriod:
+		report.add("period", checkFail, fmt.Sprintf("%d year(s) is below the .%s minimum of %d", period, tld.TLD, tld.MinPeriod))
+	case tld.MaxPeriod > 0 && period > tld.MaxPeriod:
+		report.add("period", checkFail, fmt.Sprintf("%d year(s) exceeds the .%s maximum of %d", period, tld.TLD, tld.MaxPeriod))
+	default:
+		report.add("period", checkOK, fmt.Sprintf("%d year(s)", period))
+	}
+}
+
+func (p *preflighter) checkContacts(ctx context.Context, report *preflightReport, tld *api.TLDInfo, req *api.RegisterRequest) error {
+	roles := []struct {
+		role   string
+		handle string
+	}{
+		{roleRegistrant, req.Registrant},
+		{roleAdmin, req.Admin},
+		{roleTech, req.Tech},
+		{roleBilling, req.Billing},
+	}
+
+	for _, r := range roles {
+		name := "contact " + r.role
+		if r.handle == "" {
+			if r.role == roleRegistrant {
+				report.add(name, checkFail, "no registrant given")
+			}
+			continue
+		}
+
+		contact, err := p.contact(ctx, r.handle)
+		if err != nil {
+			var notFound *api.NotFoundError
+			if !errors.As(err, &notFound) {
+				return err
+			}
+			report.add(name, checkFail, fmt.Sprintf("%s does not exist", r.handle))
+			continue
+		}
+
+		if tld != nil && len(tld.ContactProperties) > 0 {
+			if err := api.ValidateContactProperties(tld.ContactProperties, contact.Properties[tld.Registry]); err != nil {
+				report.add(name, checkFail, fmt.Sprintf("%s: %s", r.handle, strings.ReplaceAll(err.Error(), "\n", "; ")))
+				continue
+			}
+		}
+		report.add(name, checkOK, r.handle)
+	}
+	return nil
+}
+
+func (p *preflighter) contact(ctx context.Context, handle string) (*api.Contact, error) {
+	p.mu.Lock()
+	c, ok := p.contacts[handle]
+	p.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	c, err := p.client.GetContact(ctx, p.customer, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.contacts[handle] = c
+	p.mu.Unlock()
+	return c, nil
+}
+
+func (p *preflighter) checkNameservers(ctx context.Context, report *preflightReport, nameservers []string) {
+	if len(nameservers) == 0 {
+		report.add("nameservers", checkOK, "registry defaults")
+		return
+	}
+	if len(nameservers) == 1 {
+		report.add("nameservers", checkWarn, "only one nameserver; most registries require two")
+	}
+	for _, ns := range nameservers {
+		if _, err := p.dns.ResolveServers(ctx, []string{ns}); err != nil {
+			report.add("ns "+ns, checkFail, "does not resolve")
+			continue
+		}
+		report.add("ns "+ns, checkOK, "resolves")
+	}
+}
+
+// isDomainError reports whether err is an API rejection of the request
+// itself (not found, validation, bad request) rather than a transport or
+// authentication failure.
+func isDomainError(err error) bool {
+	// Auth and rate-limit errors unwrap to *api.APIError too, so rule them
+	// out before the generic 4xx check.
+	var authErr *api.AuthError
+	var rateErr *api.RateLimitError
+	if errors.As(err, &authErr) || errors.As(err, &rateErr) {
+		return false
+	}
+	var notFound *api.NotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var apiErr *api.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// DomainPreflightCmd checks whether a registration would succeed.
+type DomainPreflightCmd struct {
+	Domain string `arg:"" help:"Domain name to check"`
+	RegisterFlags
+}
+
+func (c *DomainPreflightCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+	return runPreflightCmd(ctx, flags, client, c.Domain, c.request(c.Domain, cfg))
+}
+
+// runPreflightCmd runs and prints the checks for a single registration.
+func runPreflightCmd(ctx context.Context, flags *RootFlags, client *api.Client, domain string, req *api.RegisterRequest) error {
+	customer, err := getCustomer()
+	if err != nil {
+		return err
+	}
+
+	report, err := newPreflighter(client, customer).run(ctx, domain, req)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+	if err := renderPreflightReport(f, report); err != nil {
+		return err
+	}
+
+	if n := report.failures(); n > 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("%s: %d preflight check(s) failed", domain, n)}
+	}
+	if f.Mode == output.ModeTable {
+		fmt.Println(f.Colors.Green("All preflight checks passed."))
+	}
+	return nil
+}
+
+func renderPreflightReport(f *output.Formatter, report *preflightReport) error {
+	headers := []string{"CHECK", "STATUS", "DETAIL"}
+	rows := make([][]string, 0, len(report.Checks))
+	for _, c := range report.Checks {
+		status := c.Status
+		if f.Mode == output.ModeTable {
+			status = checkColor(f.Colors, status)
+		}
+		rows = append(rows, []string{c.Check, status, c.Detail})
+	}
+	return f.Output(report, headers, rows)
+}
+
+func checkColor(colors *output.Colors, status string) string {
+	switch status {
+	case checkOK:
+		return colors.Green(status)
+	case checkWarn:
+		return colors.Yellow(status)
+	case checkFail:
+		return colors.Red(status)
+	default:
+		return status
+	}
+}
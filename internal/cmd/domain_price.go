@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
+
+// registrationQuote is the price of registering a domain for a period.
+type registrationQuote struct {
+	Premium  bool    `json:"premium"`
+	PerYear  float64 `json:"perYear"` // 0 if unknown
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// Known reports whether a price was found.
+func (q *registrationQuote) Known() bool {
+	return q.Total > 0
+}
+
+func (q *registrationQuote) String() string {
+	if !q.Known() {
+		return "unknown price"
+	}
+	return strings.TrimSpace(fmt.Sprintf("%.2f %s", q.Total, q.Currency))
+}
+
+// quoteRegistration prices a registration from the availability check for
+// premium names, or from the customer's pricelist otherwise. avail may be
+// passed in if already fetched; customer may be empty if not configured.
+func quoteRegistration(ctx context.Context, client *api.Client, customer, domain string, period int, avail *api.DomainAvailability) (*registrationQuote, error) {
+	if avail == nil {
+		var err error
+		avail, err = client.CheckDomain(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	q := &registrationQuote{Premium: avail.Premium}
+	switch {
+	case avail.Premium:
+		q.PerYear, q.Currency = avail.Price, avail.Currency
+	case customer != "":
+		pricelist, err := client.GetPricelist(ctx, customer)
+		if err != nil {
+			return nil, err
+		}
+		if cents, cur, ok := pricelist.GetTLDPrice(domainTLD(domain)); ok {
+			q.PerYear, q.Currency = float64(cents)/100, cur
+		}
+	}
+	q.Total = q.PerYear * float64(max(period, 1))
+	return q, nil
+}
+
+// checkPremiumAcceptance requires an explicit, matching --accept-premium-price
+// for premium names. accepted is the total the user agreed to pay.
+func checkPremiumAcceptance(domain string, q *registrationQuote, accepted float64) error {
+	if !q.Premium {
+		return nil
+	}
+	if !q.Known() {
+		return fmt.Errorf("%s is a premium domain but its price could not be determined", domain)
+	}
+	if accepted == 0 {
+		return fmt.Errorf("%s is a premium domain costing %s; rerun with --accept-premium-price %.2f to register it",
+			domain, q, q.Total)
+	}
+	if !samePrice(accepted, q.Total) {
+		return fmt.Errorf("%s: accepted premium price %.2f does not match the current price %s", domain, accepted, q)
+	}
+	return nil
+}
+
+// premiumBillables returns the billables acknowledging a premium price.
+func premiumBillables(domain string, period int, q *registrationQuote) []api.Billable {
+	if !q.Premium || !q.Known() {
+		return nil
+	}
+	return []api.Billable{{
+		Product:  api.DomainProduct(domainTLD(domain)),
+		Action:   api.ActionCreate,
+		Quantity: max(period, 1),
+		Amount:   int(math.Round(q.Total * 100)),
+	}}
+}
+
+// samePrice compares two amounts to the cent.
+func samePrice(a, b float64) bool {
+	return math.Round(a*100) == math.Round(b*100)
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/cache"
+	"github.com/dedene/realtime-register-cli/internal/config"
+)
+
+func TestPreflight(t *testing.T) {
+	mock := api.NewMockServer(t)
+	defer mock.Close()
+
+	mock.OnJSON("GET", "/domains/example.eu/check", 200, api.DomainAvailability{
+		Domain: "example.eu", Available: true, Premium: true, Price: 250,
+	})
+	mock.OnJSON("GET", "/tlds/eu", 200, api.TLDInfo{
+		TLD: "eu", MinPeriod: 1, MaxPeriod: 5, Registry: "eurid",
+		ContactProperties: []api.ContactProperty{{Name: "language", Mandatory: true}},
+	})
+	mock.OnJSON("GET", "/customers/acme/contacts/jdoe", 200, api.Contact{
+		Handle: "jdoe", Properties: map[string]map[string]string{"eurid": {"language": "nl"}},
+	})
+	mock.OnJSON("GET", "/customers/acme/contacts/ops", 200, api.Contact{Handle: "ops"})
+	mock.OnJSON("GET", "/customers/acme/contacts/ghost", 404, map[string]any{
+		"error": map[string]any{"code": 404, "message": "Contact not found"},
+	})
+
+	p := newPreflighter(mock.Client(), "acme")
+	p.dns.Lookup = func(_ context.Context, host string) ([]string, error) {
+		if host == "ns1.example.net" {
+			return []string{"192.0.2.1:53"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	report, err := p.run(context.Background(), "example.eu", &api.RegisterRequest{
+		Period:      10,
+		Registrant:  "jdoe",
+		Admin:       "ghost",
+		Tech:        "ops",
+		Nameservers: []string{"ns1.example.net", "ns2.example.invalid"},
+	})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	want := map[string]string{
+		"availability":           checkOK,
+		"premium":                checkWarn,
+		"period":                 checkFail,
+		"contact registrant":     checkOK,
+		"contact admin":          checkFail,
+		"contact tech":           checkFail,
+		"ns ns1.example.net":     checkOK,
+		"ns ns2.example.invalid": checkFail,
+	}
+	got := make(map[string]string)
+	for _, c := range report.Checks {
+		got[c.Check] = c.Status
+	}
+	for check, status := range want {
+		if got[check] != status {
+			t.Errorf("%s = %q, want %q", check, got[check], status)
+		}
+	}
+	if report.OK() {
+		t.Error("OK() = true, want false")
+	}
+	if n := report.failures(); n != 4 {
+		t.Errorf("failures() = %d, want 4", n)
+	}
+}
+
+func TestPreflightAuthError(t *testing.T) {
+	mock := api.NewMockServer(t)
+	defer mock.Close()
+
+	mock.OnJSON("GET", "/domains/example.eu/check", 401, map[string]any{
+		"error": map[string]any{"code": 401, "message": "Invalid API key"},
+	})
+
+	p := newPreflighter(mock.Client(), "acme")
+	report, err := p.run(context.Background(), "example.eu", &api.RegisterRequest{Period: 1})
+	var authErr *api.AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("run() error = %v, want *api.AuthError", err)
+	}
+	if report != nil {
+		t.Errorf("run() report = %+v, want nil", report)
+	}
+}
+
+func TestLoadBulkManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	manifest := `
+defaults:
+  period: 2
+  contacts:
+    registrant: acme
+    tech: acme-tech
+  ns: [ns1.example.net, ns2.example.net]
+  privacy: true
+domains:
+  - domain: Brand.com
+  - domain: brand.eu
+    period: 1
+    contacts:
+      registrant: acme-eu
+    privacy: false
+`
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	yes := true
+	cfg := &config.File{Register: &config.RegisterDefaults{
+		Billing:   "acme-billing",
+		AutoRenew: &yes,
+		TLDs:      map[string]*config.RegisterDefaults{"eu": {Period: 3, Billing: "eu-billing"}},
+	}}
+
+	entries, err := loadBulkManifest(path, cfg)
+	if err != nil {
+		t.Fatalf("loadBulkManifest() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+
+	com, eu := entries[0], entries[1]
+	if com.Domain != "brand.com" || com.Period != 2 || com.Contacts.Registrant != "acme" || len(com.NS) != 2 {
+		t.Errorf("brand.com = %+v", com)
+	}
+	if com.Privacy == nil || !*com.Privacy {
+		t.Errorf("brand.com privacy = %v, want true", com.Privacy)
+	}
+	if eu.Period != 1 || eu.Contacts.Registrant != "acme-eu" || eu.Contacts.Tech != "acme-tech" {
+		t.Errorf("brand.eu = %+v", eu)
+	}
+	if eu.Privacy == nil || *eu.Privacy {
+		t.Errorf("brand.eu privacy = %v, want false", eu.Privacy)
+	}
+
+	// Config defaults fill what the manifest leaves unset.
+	if com.req.Billing != "acme-billing" || eu.req.Billing != "eu-billing" {
+		t.Errorf("billing = %q, %q; want config defaults", com.req.Billing, eu.req.Billing)
+	}
+	if com.req.AutoRenew == nil || !*com.req.AutoRenew {
+		t.Errorf("brand.com autoRenew = %v, want true from config", com.req.AutoRenew)
+	}
+	if eu.req.Period != 1 {
+		t.Errorf("brand.eu period = %d, want manifest value 1 over config", eu.req.Period)
+	}
+}
+
+func TestEstimateBulkCost(t *testing.T) {
+	entries := []bulkEntry{
+		{Domain: "a.com", req: &api.RegisterRequest{Period: 2}},
+		{Domain: "b.com", req: &api.RegisterRequest{Period: 1}},
+		{Domain: "c.xyz", req: &api.RegisterRequest{Period: 1}},
+		{Domain: "d.com", req: &api.RegisterRequest{Period: 1}},
+		{Domain: "e.com", req: &api.RegisterRequest{Period: 1}},
+	}
+	reports := []*preflightReport{
+		{Availability: &api.DomainAvailability{Available: true}},
+		{Availability: &api.DomainAvailability{Available: true, Premium: true, Price: 500, Currency: "EUR"}},
+		{Availability: &api.DomainAvailability{Available: true}},
+		{Availability: &api.DomainAvailability{Available: true, Premium: true, Price: 300, Currency: "USD"}},
+		{Availability: &api.DomainAvailability{Available: true, Premium: true, Price: 100}},
+	}
+	pricelist := &api.Pricelist{Prices: []api.PricelistEntry{
+		{Product: "domain_com", Action: "CREATE", Currency: "EUR", Price: 1000},
+	}}
+
+	costs := estimateBulkCost(entries, reports, pricelist)
+	if costs[0].Price != 20 || costs[1].Price != 500 || !costs[1].Premium || costs[2].Price != 0 {
+		t.Errorf("costs = %+v", costs)
+	}
+	if costs[1].currencyMismatch() {
+		t.Errorf("b.com premium in EUR flagged as mismatch: %+v", costs[1])
+	}
+	if costs[3].Currency != "USD" || costs[3].PricelistCurrency != "EUR" {
+		t.Errorf("d.com = %+v, want USD flagged against EUR pricelist", costs[3])
+	}
+	if costs[4].Currency != "" || !costs[4].currencyMismatch() {
+		t.Errorf("e.com = %+v, want unknown currency flagged", costs[4])
+	}
+	if got, want := costTotals(costs), "100.00 (unknown currency) + 520.00 EUR + 300.00 USD + 1 unpriced"; got != want {
+		t.Errorf("costTotals() = %q, want %q", got, want)
+	}
+}
+
+func TestRegisterFlagsRequest(t *testing.T) {
+	yes, no := true, false
+	cfg := &config.File{
+		AutoRenew: &yes,
+		Register: &config.RegisterDefaults{
+			Registrant:  "acme",
+			Tech:        "acme-tech",
+			Nameservers: []string{"ns1.example.net"},
+			Period:      2,
+			TLDs: map[string]*config.RegisterDefaults{
+				"eu": {Registrant: "acme-eu", Privacy: &no},
+			},
+		},
+	}
+
+	req := (&RegisterFlags{}).request("example.com", cfg)
+	if req.Registrant != "acme" || req.Tech != "acme-tech" || req.Period != 2 || len(req.Nameservers) != 1 {
+		t.Errorf("example.com req = %+v", req)
+	}
+	if req.AutoRenew == nil || !*req.AutoRenew {
+		t.Errorf("AutoRenew = %v, want global auto_renew", req.AutoRenew)
+	}
+	if req.PrivacyProxy != nil {
+		t.Errorf("PrivacyProxy = %v, want unset", *req.PrivacyProxy)
+	}
+
+	req = (&RegisterFlags{Tech: "ops", Period: 1, AutoRenew: &no}).request("example.eu", cfg)
+	if req.Registrant != "acme-eu" || req.Tech != "ops" || req.Period != 1 {
+		t.Errorf("example.eu req = %+v", req)
+	}
+	if req.AutoRenew == nil || *req.AutoRenew || req.PrivacyProxy == nil || *req.PrivacyProxy {
+		t.Errorf("flags and TLD overrides not applied: %+v", req)
+	}
+
+	if req := (&RegisterFlags{}).request("example.com", &config.File{}); req.Period != 1 {
+		t.Errorf("Period = %d, want 1 without config", req.Period)
+	}
+}
+
+func TestQuoteRegistration(t *testing.T) {
+	mock := api.NewMockServer(t)
+	defer mock.Close()
+
+	mock.OnJSON("GET", "/customers/acme/pricelist", 200, api.Pricelist{Prices: []api.PricelistEntry{
+		{Product: "domain_com", Action: "CREATE", Currency: "EUR", Price: 1050},
+	}})
+	client := mock.Client()
+	ctx := context.Background()
+
+	q, err := quoteRegistration(ctx, client, "acme", "example.com", 2, &api.DomainAvailability{Available: true})
+	if err != nil {
+		t.Fatalf("quoteRegistration() error = %v", err)
+	}
+	if q.Premium || q.String() != "21.00 EUR" {
+		t.Errorf("quote = %+v (%s), want 21.00 EUR", q, q)
+	}
+	if err := checkPremiumAcceptance("example.com", q, 0); err != nil {
+		t.Errorf("checkPremiumAcceptance() non-premium error = %v", err)
+	}
+
+	premium := &api.DomainAvailability{Available: true, Premium: true, Price: 400, Currency: "USD"}
+	q, err = quoteRegistration(ctx, client, "acme", "cool.com", 1, premium)
+	if err != nil {
+		t.Fatalf("quoteRegistration() error = %v", err)
+	}
+	if !q.Premium || q.String() != "400.00 USD" {
+		t.Errorf("premium quote = %+v", q)
+	}
+	if err := checkPremiumAcceptance("cool.com", q, 0); err == nil {
+		t.Error("expected error without --accept-premium-price")
+	}
+	if err := checkPremiumAcceptance("cool.com", q, 350); err == nil {
+		t.Error("expected error for mismatched accepted price")
+	}
+	if err := checkPremiumAcceptance("cool.com", q, 400); err != nil {
+		t.Errorf("checkPremiumAcceptance(400) error = %v", err)
+	}
+
+	b := premiumBillables("cool.com", 1, q)
+	if len(b) != 1 || b[0].Amount != 40000 || b[0].Product != "domain_com" {
+		t.Errorf("premiumBillables() = %+v", b)
+	}
+}
+
+func TestQuoteRegistrationRefreshed(t *testing.T) {
+	mock := api.NewMockServer(t)
+	defer mock.Close()
+
+	prices := []int{1050, 1200}
+	calls := 0
+	mock.On("GET", "/customers/acme/pricelist", func(w http.ResponseWriter, _ *http.Request) {
+		price := prices[min(calls, len(prices)-1)]
+		calls++
+		_ = json.NewEncoder(w).Encode(api.Pricelist{Prices: []api.PricelistEntry{
+			{Product: "domain_com", Action: "CREATE", Currency: "EUR", Price: price},
+		}})
+	})
+	client := mock.Client()
+	client.SetCache(cache.New(t.TempDir()))
+	ctx := context.Background()
+	avail := &api.DomainAvailability{Available: true}
+
+	q, err := quoteRegistration(ctx, client, "acme", "example.com", 1, avail)
+	if err != nil {
+		t.Fatalf("quoteRegistration() error = %v", err)
+	}
+	if q.String() != "10.50 EUR" {
+		t.Errorf("first quote = %s, want 10.50 EUR", q)
+	}
+	if cached, err := quoteRegistration(ctx, client, "acme", "example.com", 1, avail); err != nil || calls != 1 {
+		t.Fatalf("cached quote = %v, %v after %d fetches, want it from the cache", cached, err, calls)
+	}
+
+	current, err := quoteRegistration(ctx, client.Refreshed(), "acme", "example.com", 1, avail)
+	if err != nil {
+		t.Fatalf("quoteRegistration() error = %v", err)
+	}
+	if current.String() != "12.00 EUR" {
+		t.Errorf("refreshed quote = %s, want the changed price 12.00 EUR", current)
+	}
+	if calls != 2 {
+		t.Errorf("pricelist fetched %d times, want 2", calls)
+	}
+}
+
+func TestForEachParallel(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	seen := make([]bool, 20)
+
+	err := forEachParallel(context.Background(), len(seen), 3, func(_ context.Context, i int) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		seen[i] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("forEachParallel() error = %v", err)
+	}
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak)
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("index %d not visited", i)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err = forEachParallel(ctx, 5, 1, func(context.Context, int) { calls++ })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("forEachParallel() on cancelled context = %v, want context.Canceled", err)
+	}
+	if calls > 1 {
+		t.Errorf("forEachParallel() made %d calls after cancel", calls)
+	}
+}
@@ -1,17 +1,51 @@
 package cmd
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
 
 // Exit code constants.
 const (
-	CodeSuccess   = 0
-	CodeError     = 1
-	CodeUsage     = 2
-	CodeAuth      = 3
-	CodeAPI       = 4
-	CodeRateLimit = 5
+	CodeSuccess    = 0
+	CodeError      = 1
+	CodeUsage      = 2
+	CodeAuth       = 3
+	CodeAPI        = 4
+	CodeRateLimit  = 5
+	CodeNotFound   = 6
+	CodeValidation = 7
+	CodeConflict   = 8
+	CodeNetwork    = 9
+	CodeCancelled  = 130 // interrupted, as shells report SIGINT
 )
 
+// exitCodeKinds name the exit codes in JSON error output.
+var exitCodeKinds = map[int]string{
+	CodeError:      "error",
+	CodeUsage:      "usage",
+	CodeAuth:       "auth",
+	CodeAPI:        "api",
+	CodeRateLimit:  "rate_limit",
+	CodeNotFound:   "not_found",
+	CodeValidation: "validation",
+	CodeConflict:   "conflict",
+	CodeNetwork:    "network",
+	CodeCancelled:  "cancelled",
+}
+
+// exitCodeKind returns the name of an exit code.
+func exitCodeKind(code int) string {
+	if kind, ok := exitCodeKinds[code]; ok {
+		return kind
+	}
+	return "error"
+}
+
 // ExitError wraps an error with a process exit code.
 type ExitError struct {
 	Code int
@@ -39,3 +73,41 @@ func ExitCode(err error) int {
 	}
 	return CodeError
 }
+
+// apiExitError wraps an error returned by the API client with the exit code
+// for its kind of failure.
+func apiExitError(err error) *ExitError {
+	return &ExitError{Code: apiExitCode(err), Err: err}
+}
+
+// apiExitCode maps an API client error to an exit code. Errors that are not
+// recognised get CodeAPI.
+func apiExitCode(err error) int {
+	var (
+		authErr       *api.AuthError
+		rateLimitErr  *api.RateLimitError
+		notFoundErr   *api.NotFoundError
+		badRequestErr *api.BadRequestError
+		unprocessable *api.UnprocessableEntityError
+		validationErr *api.ValidationError
+		conflictErr   *api.ConflictError
+		netErr        net.Error
+		urlErr        *url.Error
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return CodeAuth
+	case errors.As(err, &rateLimitErr):
+		return CodeRateLimit
+	case errors.As(err, &notFoundErr):
+		return CodeNotFound
+	case errors.As(err, &badRequestErr), errors.As(err, &unprocessable), errors.As(err, &validationErr):
+		return CodeValidation
+	case errors.As(err, &conflictErr):
+		return CodeConflict
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr), errors.As(err, &urlErr):
+		return CodeNetwork
+	default:
+		return CodeAPI
+	}
+}
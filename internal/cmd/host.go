@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// HostCmd is the parent command for host (glue) operations.
+type HostCmd struct {
+	List   HostListCmd   `cmd:"" help:"List hosts"`
+	Get    HostGetCmd    `cmd:"" help:"Get host details"`
+	Create HostCreateCmd `cmd:"" help:"Create a host with glue addresses"`
+	Update HostUpdateCmd `cmd:"" help:"Update host addresses"`
+	Delete HostDeleteCmd `cmd:"" help:"Delete a host"`
+}
+
+// HostListCmd lists hosts.
+type HostListCmd struct {
+	Search string `help:"Search query"`
+	Limit  int    `help:"Max results" default:"50"`
+	Offset int    `help:"Offset for pagination"`
+}
+
+func (c *HostListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	opts := api.HostListOptions{
+		ListOptions: api.ListOptions{
+			Limit:  c.Limit,
+			Offset: c.Offset,
+			Search: c.Search,
+		},
+	}
+
+	resp, err := client.ListHosts(ctx, opts)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	headers := []string{"HOST", "IPV4", "IPV6"}
+	rows := make([][]string, 0, len(resp.Entities))
+	for i := range resp.Entities {
+		h := &resp.Entities[i]
+		rows = append(rows, []string{
+			h.HostName,
+			strings.Join(hostIPs(h.Addresses, api.IPv4), ", "),
+			strings.Join(hostIPs(h.Addresses, api.IPv6), ", "),
+		})
+	}
+
+	return f.Output(resp.Entities, headers, rows)
+}
+
+// HostGetCmd gets a single host.
+type HostGetCmd struct {
+	Host string `arg:"" help:"Host name (e.g., ns1.example.com)"`
+}
+
+func (c *HostGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	host, err := client.GetHost(ctx, c.Host)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	kvPairs := [][2]string{
+		{"Host", host.HostName},
+		{"IPv4", strings.Join(hostIPs(host.Addresses, api.IPv4), ", ")},
+		{"IPv6", strings.Join(hostIPs(host.Addresses, api.IPv6), ", ")},
+		{"Created", host.CreatedDate.Format("2006-01-02")},
+	}
+
+	return f.OutputSingle(host, kvPairs)
+}
+
+// HostCreateCmd creates a host.
+type HostCreateCmd struct {
+	Host string   `arg:"" help:"Host name (e.g., ns1.example.com)"`
+	IPv4 []string `help:"IPv4 glue address (repeatable)" name:"ipv4"`
+	IPv6 []string `help:"IPv6 glue address (repeatable)" name:"ipv6"`
+}
+
+func (c *HostCreateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	addrs, err := hostAddresses(c.IPv4, c.IPv6)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+	if len(addrs) == 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("at least one --ipv4 or --ipv6 address is required")}
+	}
+
+	if err := client.CreateHost(ctx, c.Host, &api.HostRequest{Addresses: addrs}); err != nil {
+		return apiExitError(err)
+	}
+
+	fmt.Printf("Host %s created.\n", c.Host)
+	return nil
+}
+
+// HostUpdateCmd updates a host's addresses.
+type HostUpdateCmd struct {
+	Host string   `arg:"" help:"Host name"`
+	IPv4 []string `help:"Replace IPv4 glue addresses (repeatable)" name:"ipv4"`
+	IPv6 []string `help:"Replace IPv6 glue addresses (repeatable)" name:"ipv6"`
+}
+
+func (c *HostUpdateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if len(c.IPv4) == 0 && len(c.IPv6) == 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("nothing to update; use --ipv4 and/or --ipv6")}
+	}
+
+	// Keep the existing addresses of any IP version not given on the command line.
+	host, err := client.GetHost(ctx, c.Host)
+	if err != nil {
+		return apiExitError(err)
+	}
+	ipv4, ipv6 := c.IPv4, c.IPv6
+	if len(ipv4) == 0 {
+		ipv4 = hostIPs(host.Addresses, api.IPv4)
+	}
+	if len(ipv6) == 0 {
+		ipv6 = hostIPs(host.Addresses, api.IPv6)
+	}
+
+	addrs, err := hostAddresses(ipv4, ipv6)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	if err := client.UpdateHost(ctx, c.Host, &api.HostRequest{Addresses: addrs}); err != nil {
+		return apiExitError(err)
+	}
+
+	fmt.Printf("Host %s updated.\n", c.Host)
+	return nil
+}
+
+// HostDeleteCmd deletes a host.
+type HostDeleteCmd struct {
+	Host string `arg:"" help:"Host name to delete"`
+}
+
+func (c *HostDeleteCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	if !flags.Yes {
+		fmt.Printf("Delete host %s? [y/N]: ", c.Host)
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	if err := client.DeleteHost(ctx, c.Host); err != nil {
+		return apiExitError(err)
+	}
+
+	fmt.Printf("Host %s deleted.\n", c.Host)
+	return nil
+}
+
+// hostAddresses validates IPv4 and IPv6 flags and builds host addresses.
+func hostAddresses(ipv4, ipv6 []string) ([]api.HostAddress, error) {
+	addrs, err := api.NewHostAddresses(append(append([]string{}, ipv4...), ipv6...)...)
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range addrs {
+		want := api.IPv4
+		if i >= len(ipv4) {
+			want = api.IPv6
+		}
+		if a.IPVersion != want {
+			return nil, fmt.Errorf("%s is not an %s address", a.Address, ipVersionName(want))
+		}
+	}
+	return addrs, nil
+}
+
+// hostIPs returns the addresses of the given IP version.
+func hostIPs(addrs []api.HostAddress, version string) []string {
+	var ips []string
+	for _, a := range addrs {
+		if a.IPVersion == version {
+			ips = append(ips, a.Address)
+		}
+	}
+	return ips
+}
+
+func ipVersionName(version string) string {
+	if version == api.IPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
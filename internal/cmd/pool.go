@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+)
+
+// forEachParallel calls fn for every index in [0, n) from at most workers
+// goroutines and waits for them to finish. Once ctx is done no further
+// calls are started; it returns ctx.Err() if any index was skipped.
+//
+// Throttling is left to the API client's rate limiter, which all workers
+// share.
+func forEachParallel(ctx context.Context, n, workers int, fn func(ctx context.Context, i int)) error {
+	workers = max(1, min(workers, n))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// PriceCmd is the parent command for pricelist operations.
+type PriceCmd struct {
+	List PriceListCmd `cmd:"" help:"List domain prices per TLD"`
+	Get  PriceGetCmd  `cmd:"" help:"Get prices for a TLD"`
+}
+
+// PriceListCmd lists prices from the customer pricelist.
+type PriceListCmd struct {
+	Search   string `help:"Only TLDs containing this text"`
+	Currency string `help:"Only prices in this currency"`
+	Sort     string `help:"Sort by tld, create, renew, transfer or restore (prefix - for descending)" short:"s" default:"tld"`
+	CSV      bool   `help:"Output CSV"`
+}
+
+func (c *PriceListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	less, err := priceSorter(c.Sort)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	prices, err := fetchTLDPrices(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]api.TLDPrice, 0, len(prices))
+	search := strings.TrimPrefix(strings.ToLower(c.Search), ".")
+	for _, p := range prices {
+		if search != "" && !strings.Contains(p.TLD, search) {
+			continue
+		}
+		if c.Currency != "" && !strings.EqualFold(p.Currency, c.Currency) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return less(&filtered[i], &filtered[j]) })
+
+	return outputTLDPrices(flags, c.CSV, filtered)
+}
+
+// PriceGetCmd shows the prices for a single TLD.
+type PriceGetCmd struct {
+	TLD string `arg:"" help:"TLD name (e.g., com, eu)"`
+	CSV bool   `help:"Output CSV"`
+}
+
+func (c *PriceGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	prices, err := fetchTLDPrices(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	tld := strings.TrimPrefix(strings.ToLower(c.TLD), ".")
+	var matched []api.TLDPrice
+	for _, p := range prices {
+		if p.TLD == tld {
+			matched = append(matched, p)
+		}
+	}
+	if len(matched) == 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("no prices for .%s in your pricelist", tld)}
+	}
+
+	return outputTLDPrices(flags, c.CSV, matched)
+}
+
+func fetchTLDPrices(ctx context.Context, client *api.Client) ([]api.TLDPrice, error) {
+	customer, err := getCustomer()
+	if err != nil {
+		return nil, err
+	}
+
+	pricelist, err := client.GetPricelist(ctx, customer)
+	if err != nil {
+		return nil, apiExitError(err)
+	}
+	return pricelist.ByTLD(), nil
+}
+
+func outputTLDPrices(flags *RootFlags, csv bool, prices []api.TLDPrice) error {
+	headers := []string{"TLD", "CURRENCY", "CREATE", "RENEW", "TRANSFER", "RESTORE"}
+	missing := "-"
+	if csv {
+		missing = ""
+	}
+	rows := make([][]string, 0, len(prices))
+	for _, p := range prices {
+		rows = append(rows, []string{
+			p.TLD,
+			p.Currency,
+			formatCents(p.Create, missing),
+			formatCents(p.Renew, missing),
+			formatCents(p.Transfer, missing),
+			formatCents(p.Restore, missing),
+		})
+	}
+
+	if csv {
+		return output.WriteCSV(os.Stdout, headers, rows)
+	}
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+	return f.Output(prices, headers, rows)
+}
+
+// formatCents renders an amount in cents as units, or missing if negative.
+func formatCents(cents int, missing string) string {
+	if cents < 0 {
+		return missing
+	}
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// priceSorter returns a comparison for a --sort value such as "renew" or "-create".
+func priceSorter(field string) (func(a, b *api.TLDPrice) bool, error) {
+	desc := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	var key func(p *api.TLDPrice) int
+	switch strings.ToLower(field) {
+	case "", "tld":
+		return func(a, b *api.TLDPrice) bool {
+			if a.TLD != b.TLD {
+				return (a.TLD < b.TLD) != desc
+			}
+			return a.Currency < b.Currency
+		}, nil
+	case "create":
+		key = func(p *api.TLDPrice) int { return p.Create }
+	case "renew":
+		key = func(p *api.TLDPrice) int { return p.Renew }
+	case "transfer":
+		key = func(p *api.TLDPrice) int { return p.Transfer }
+	case "restore":
+		key = func(p *api.TLDPrice) int { return p.Restore }
+	default:
+		return nil, fmt.Errorf("unknown sort field %q (use tld, create, renew, transfer or restore)", field)
+	}
+
+	return func(a, b *api.TLDPrice) bool {
+		ka, kb := key(a), key(b)
+		// TLDs without a price for the action always sort last.
+		if (ka < 0) != (kb < 0) {
+			return kb < 0
+		}
+		if ka != kb {
+			return (ka < kb) != desc
+		}
+		return a.TLD < b.TLD
+	}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
 	"github.com/dedene/realtime-register-cli/internal/output"
@@ -25,16 +27,7 @@ type ProcessListCmd struct {
 	Offset int    `help:"Offset for pagination"`
 }
 
-func (c *ProcessListCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
-
+func (c *ProcessListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	opts := api.ProcessListOptions{
 		ListOptions: api.ListOptions{
 			Limit:  c.Limit,
@@ -45,7 +38,7 @@ func (c *ProcessListCmd) Run(flags *RootFlags) error {
 
 	resp, err := client.ListProcesses(ctx, opts)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -71,18 +64,10 @@ type ProcessGetCmd struct {
 	ID int `arg:"" help:"Process ID"`
 }
 
-func (c *ProcessGetCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *ProcessGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	process, err := client.GetProcess(ctx, c.ID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -103,18 +88,10 @@ type ProcessInfoCmd struct {
 	ID int `arg:"" help:"Process ID"`
 }
 
-func (c *ProcessInfoCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *ProcessInfoCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	info, err := client.GetProcessInfo(ctx, c.ID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -139,14 +116,7 @@ type ProcessCancelCmd struct {
 	ID int `arg:"" help:"Process ID to cancel"`
 }
 
-func (c *ProcessCancelCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ProcessCancelCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if !flags.Yes {
 		fmt.Printf("Cancel process %d? [y/N]: ", c.ID)
 		var response string
@@ -157,9 +127,8 @@ func (c *ProcessCancelCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	if err := client.CancelProcess(ctx, c.ID); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Process %d cancelled.\n", c.ID)
@@ -171,19 +140,44 @@ type ProcessResendCmd struct {
 	ID int `arg:"" help:"Process ID"`
 }
 
-func (c *ProcessResendCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *ProcessResendCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if err := client.ResendProcess(ctx, c.ID); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Notifications resent for process %d.\n", c.ID)
 	return nil
 }
+
+// processDone reports whether a process status is final.
+func processDone(status string) bool {
+	switch strings.ToLower(status) {
+	case "completed", "failed", "cancelled", "canceled":
+		return true
+	default:
+		return false
+	}
+}
+
+// waitForProcess polls a process every interval until it reaches a final
+// status or ctx is done. The last fetched process is returned with ctx's error.
+func waitForProcess(ctx context.Context, client *api.Client, id int, interval time.Duration) (*api.Process, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		process, err := client.GetProcess(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if processDone(process.Status) {
+			return process, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return process, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
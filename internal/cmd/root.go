@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/alecthomas/kong"
 
@@ -12,11 +16,20 @@ import (
 
 // RootFlags are global flags available to all commands.
 type RootFlags struct {
-	JSON    bool   `help:"Output JSON to stdout" short:"j" env:"RR_JSON"`
-	Plain   bool   `help:"Output plain TSV (for scripting)" env:"RR_PLAIN"`
-	Verbose bool   `help:"HTTP debug logging" short:"v"`
-	Yes     bool   `help:"Skip confirmation prompts" short:"y"`
-	Color   string `help:"Color mode: auto|always|never" default:"auto" enum:"auto,always,never"`
+	JSON      bool   `help:"Output JSON to stdout" short:"j" env:"RR_JSON"`
+	Plain     bool   `help:"Output plain TSV (for scripting)" env:"RR_PLAIN"`
+	Verbose   bool   `help:"HTTP debug logging" short:"v"`
+	TraceFile string `help:"Write a HAR trace of API requests to this file" type:"path"`
+
+	Endpoint     string        `help:"API endpoint: production, sandbox or a URL" env:"RR_ENDPOINT"`
+	HTTPTimeout  time.Duration `help:"Timeout for each API request (e.g. 30s)" env:"RR_HTTP_TIMEOUT" name:"http-timeout"`
+	Retries      *int          `help:"Retries for failed API requests (idempotent or rate-limited only)" env:"RR_RETRIES"`
+	RetryBackoff time.Duration `help:"Delay before the first retry, doubled for each further retry" env:"RR_RETRY_BACKOFF"`
+	RateLimit    float64       `help:"Maximum API requests per second, across parallel workers (0 for none)" env:"RR_RATE_LIMIT"`
+	Timeout      time.Duration `help:"Abort the whole command after this long (e.g. 5m)" env:"RR_TIMEOUT"`
+	NoCache      bool          `help:"Fetch pricelists and TLD data from the API instead of the cache" env:"RR_NO_CACHE"`
+	Yes          bool          `help:"Skip confirmation prompts" short:"y"`
+	Color        string        `help:"Color mode: auto|always|never" default:"auto" enum:"auto,always,never"`
 }
 
 // CLI is the top-level Kong CLI struct.
@@ -29,11 +42,15 @@ type CLI struct {
 	Process    ProcessCmd       `cmd:"" help:"Process commands"`
 	Contact    ContactCmd       `cmd:"" help:"Contact commands"`
 	Zone       ZoneCmd          `cmd:"" help:"DNS zone commands"`
+	Host       HostCmd          `cmd:"" help:"Host (glue) commands"`
 
 	Auth       AuthCmd       `cmd:"" help:"Manage API key"`
 	Config     ConfigCmd     `cmd:"" help:"Manage configuration"`
 	Status     StatusCmd     `cmd:"" help:"Show account status"`
 	TLD        TLDCmd        `cmd:"" name:"tld" help:"TLD commands"`
+	Price      PriceCmd      `cmd:"" help:"Pricelist commands"`
+	Cost       CostCmd       `cmd:"" help:"Cost reporting"`
+	Cache      CacheCmd      `cmd:"" help:"Manage the API response cache"`
 	Completion CompletionCmd `cmd:"" help:"Generate shell completions"`
 }
 
@@ -41,8 +58,9 @@ type exitPanic struct{ code int }
 
 // Execute runs the CLI with the given arguments.
 func Execute(args []string) (err error) {
-	parser, err := newParser()
+	parser, cli, err := newParser()
 	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return err
 	}
 
@@ -71,15 +89,61 @@ func Execute(args []string) (err error) {
 		return parsedErr
 	}
 
+	ctx, cancel := commandContext(cli.Timeout)
+	defer cancel()
+	kctx.BindTo(ctx, (*context.Context)(nil))
+
 	err = kctx.Run()
+	err = contextError(ctx, cli.Timeout, err)
+	if traceErr := writeTrace(); traceErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: could not write trace file: %v\n", traceErr)
+	}
 	if err != nil {
-		_, _ = fmt.Fprint(os.Stderr, errfmt.Format(err))
+		if cli.JSON {
+			code := ExitCode(err)
+			_, _ = fmt.Fprint(os.Stderr, errfmt.FormatJSON(err, code, exitCodeKind(code)))
+		} else {
+			_, _ = fmt.Fprint(os.Stderr, errfmt.Format(err))
+		}
 		return err
 	}
 
 	return nil
 }
 
+// commandContext returns the context commands run in. It is cancelled on
+// SIGINT or SIGTERM, and after timeout if that is set. Once cancelled, a
+// second signal terminates the process as usual.
+func commandContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+
+	if timeout <= 0 {
+		return ctx, stop
+	}
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	return tctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// contextError replaces the error of a command that failed because its
+// context was interrupted or timed out, so that it exits with the matching
+// code and message.
+func contextError(ctx context.Context, timeout time.Duration, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch ctx.Err() {
+	case context.Canceled:
+		return &ExitError{Code: CodeCancelled, Err: errors.New("interrupted")}
+	case context.DeadlineExceeded:
+		return &ExitError{Code: CodeNetwork, Err: fmt.Errorf("timed out after %s: %w", timeout, err)}
+	}
+	return err
+}
+
 func wrapParseError(err error) error {
 	if err == nil {
 		return nil
@@ -91,7 +155,7 @@ func wrapParseError(err error) error {
 	return err
 }
 
-func newParser() (*kong.Kong, error) {
+func newParser() (*kong.Kong, *CLI, error) {
 	cli := &CLI{}
 	parser, err := kong.New(
 		cli,
@@ -103,10 +167,11 @@ func newParser() (*kong.Kong, error) {
 		kong.Help(helpPrinter),
 		kong.ConfigureHelp(helpOptions()),
 		kong.Bind(&cli.RootFlags),
+		kong.BindSingletonProvider(newAPIClient),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return parser, nil
+	return parser, cli, nil
 }
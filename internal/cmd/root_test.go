@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewParser(t *testing.T) {
+	// Kong validates the whole command tree here, so clashing flag names
+	// or bad tags anywhere fail this test.
+	parser, cli, err := newParser()
+	if err != nil {
+		t.Fatalf("newParser() error = %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"version"},
+		{"domain", "check-ns", "example.com", "--query-timeout", "2s"},
+		{"zone", "verify", "1", "--query-timeout", "2s"},
+		{"--timeout", "1m", "domain", "list"},
+	} {
+		if _, err := parser.Parse(args); err != nil {
+			t.Errorf("Parse(%q) error = %v", args, err)
+		}
+	}
+	if cli.Timeout != time.Minute {
+		t.Errorf("Timeout = %s, want 1m", cli.Timeout)
+	}
+}
@@ -14,26 +14,17 @@ import (
 // StatusCmd shows account status.
 type StatusCmd struct{}
 
-func (c *StatusCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *StatusCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	cfg, err := config.ReadConfig()
 	if err != nil {
 		return &ExitError{Code: CodeError, Err: err}
 	}
 
-	client := api.NewClient(apiKey)
-
 	domains, err := client.ListDomains(ctx, api.DomainListOptions{
 		ListOptions: api.ListOptions{Limit: 1},
 	})
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	expiring, err := client.ListDomains(ctx, api.DomainListOptions{
@@ -41,7 +32,7 @@ func (c *StatusCmd) Run(flags *RootFlags) error {
 		ExpiringWithin: 30,
 	})
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	processes, err := client.ListProcesses(ctx, api.ProcessListOptions{
@@ -49,7 +40,7 @@ func (c *StatusCmd) Run(flags *RootFlags) error {
 		Status:      "pending",
 	})
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
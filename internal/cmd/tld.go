@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
 	"github.com/dedene/realtime-register-cli/internal/output"
@@ -11,8 +12,9 @@ import (
 
 // TLDCmd is the parent command for TLD operations.
 type TLDCmd struct {
-	List TLDListCmd `cmd:"" help:"List available TLDs"`
-	Get  TLDGetCmd  `cmd:"" help:"Get TLD details"`
+	List       TLDListCmd       `cmd:"" help:"List available TLDs"`
+	Get        TLDGetCmd        `cmd:"" help:"Get TLD details"`
+	Properties TLDPropertiesCmd `cmd:"" help:"List contact properties a TLD requires"`
 }
 
 // TLDListCmd lists TLDs.
@@ -22,15 +24,7 @@ type TLDListCmd struct {
 	Offset int    `help:"Offset for pagination"`
 }
 
-func (c *TLDListCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *TLDListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	opts := api.TLDListOptions{
 		ListOptions: api.ListOptions{
 			Limit:  c.Limit,
@@ -41,7 +35,7 @@ func (c *TLDListCmd) Run(flags *RootFlags) error {
 
 	resp, err := client.ListTLDs(ctx, opts)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -65,18 +59,10 @@ type TLDGetCmd struct {
 	TLD string `arg:"" help:"TLD name (e.g., com, net, io)"`
 }
 
-func (c *TLDGetCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *TLDGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	tld, err := client.GetTLD(ctx, c.TLD)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -89,6 +75,50 @@ func (c *TLDGetCmd) Run(flags *RootFlags) error {
 		{"Min Period", fmt.Sprintf("%d", tld.MinPeriod)},
 		{"Max Period", fmt.Sprintf("%d", tld.MaxPeriod)},
 	}
+	if tld.Registry != "" {
+		kvPairs = append(kvPairs, [2]string{"Registry", tld.Registry})
+	}
 
 	return f.OutputSingle(tld, kvPairs)
 }
+
+// TLDPropertiesCmd lists the registry-specific contact properties for a TLD.
+type TLDPropertiesCmd struct {
+	TLD string `arg:"" help:"TLD name (e.g., eu, it)"`
+}
+
+func (c *TLDPropertiesCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	tld, err := client.GetTLD(ctx, strings.TrimPrefix(c.TLD, "."))
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if len(tld.ContactProperties) == 0 && f.Mode == output.ModeTable {
+		fmt.Printf(".%s has no registry-specific contact properties.\n", tld.TLD)
+		return nil
+	}
+
+	headers := []string{"PROPERTY", "REQUIRED", "TYPE", "VALUES", "DESCRIPTION"}
+	rows := make([][]string, 0, len(tld.ContactProperties))
+	for _, p := range tld.ContactProperties {
+		required := "no"
+		if p.Mandatory {
+			required = "yes"
+		}
+		desc := p.Description
+		if desc == "" {
+			desc = p.Label
+		}
+		rows = append(rows, []string{
+			p.Name,
+			required,
+			p.Type,
+			strings.Join(p.AllowedValues(), ", "),
+			desc,
+		})
+	}
+
+	return f.Output(tld.ContactProperties, headers, rows)
+}
@@ -21,6 +21,11 @@ type ZoneCmd struct {
 	Delete ZoneDeleteCmd `cmd:"" help:"Delete a DNS zone"`
 	Sync   ZoneSyncCmd   `cmd:"" help:"Sync zone from YAML file"`
 	Record ZoneRecordCmd `cmd:"" help:"Manage DNS records"`
+
+	ApplyTemplate ZoneApplyTemplateCmd `cmd:"" name:"apply-template" help:"Apply a record template to a zone"`
+	Templates     ZoneTemplatesCmd     `cmd:"" help:"List or show zone templates"`
+
+	Verify ZoneVerifyCmd `cmd:"" help:"Verify nameservers serve the zone records"`
 }
 
 // ZoneListCmd lists zones.
@@ -30,15 +35,7 @@ type ZoneListCmd struct {
 	Offset int    `help:"Offset for pagination"`
 }
 
-func (c *ZoneListCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *ZoneListCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	opts := api.ZoneListOptions{
 		ListOptions: api.ListOptions{
 			Limit:  c.Limit,
@@ -49,18 +46,19 @@ func (c *ZoneListCmd) Run(flags *RootFlags) error {
 
 	resp, err := client.ListZones(ctx, opts)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
 
-	headers := []string{"ID", "NAME", "RECORDS"}
+	headers := []string{"ID", "NAME", "SERVICE", "RECORDS"}
 	rows := make([][]string, 0, len(resp.Entities))
 	for i := range resp.Entities {
 		z := &resp.Entities[i]
 		rows = append(rows, []string{
 			fmt.Sprintf("%d", z.ID),
 			z.Name,
+			z.Service,
 			fmt.Sprintf("%d", len(z.Records)),
 		})
 	}
@@ -73,18 +71,10 @@ type ZoneGetCmd struct {
 	ID int `arg:"" help:"Zone ID"`
 }
 
-func (c *ZoneGetCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
+func (c *ZoneGetCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	zone, err := client.GetZone(ctx, c.ID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
@@ -93,11 +83,26 @@ func (c *ZoneGetCmd) Run(flags *RootFlags) error {
 		return f.Output(zone, nil, nil)
 	}
 
+	dnssec := "no"
+	if zone.DNSSec {
+		dnssec = "yes"
+		if zone.DNSSecMode != "" {
+			dnssec += " (" + zone.DNSSecMode + ")"
+		}
+	}
+
 	kvPairs := [][2]string{
 		{"ID", fmt.Sprintf("%d", zone.ID)},
 		{"Name", zone.Name},
-		{"Records", fmt.Sprintf("%d", len(zone.Records))},
+		{"Service", zone.Service},
+		{"Default TTL", fmt.Sprintf("%d", zone.TTL)},
+		{"Hostmaster", zone.HostMaster},
+		{"Refresh", fmt.Sprintf("%d", zone.Refresh)},
+		{"Retry", fmt.Sprintf("%d", zone.Retry)},
+		{"Expire", fmt.Sprintf("%d", zone.Expire)},
+		{"DNSSEC", dnssec},
 	}
+	kvPairs = append(kvPairs, [2]string{"Records", fmt.Sprintf("%d", len(zone.Records))})
 
 	if err := f.OutputSingle(zone, kvPairs); err != nil {
 		return err
@@ -122,37 +127,65 @@ func (c *ZoneGetCmd) Run(flags *RootFlags) error {
 	return nil
 }
 
+// ZoneSettings are the SOA flags shared by zone create and update.
+type ZoneSettings struct {
+	HostMaster string `help:"SOA hostmaster address" name:"hostmaster"`
+	Refresh    int    `help:"SOA refresh interval in seconds"`
+	Retry      int    `help:"SOA retry interval in seconds"`
+	Expire     int    `help:"SOA expire time in seconds"`
+	DNSSecMode string `help:"DNSSEC mode" name:"dnssec-mode"`
+}
+
+// apply copies the settings onto a zone request.
+func (s *ZoneSettings) apply(req *api.ZoneRequest) {
+	req.HostMaster = s.HostMaster
+	req.Refresh = s.Refresh
+	req.Retry = s.Retry
+	req.Expire = s.Expire
+	req.DNSSecMode = s.DNSSecMode
+}
+
 // ZoneCreateCmd creates a zone.
 type ZoneCreateCmd struct {
-	Name string `arg:"" help:"Zone name (domain)"`
-	TTL  int    `help:"Default TTL" default:"3600"`
-}
+	Name     string            `arg:"" help:"Zone name (domain)"`
+	TTL      int               `help:"Default TTL" default:"3600"`
+	Template []string          `help:"Seed records from template (repeatable)" short:"T"`
+	Var      map[string]string `help:"Template variable (key=value)" short:"V"`
 
-func (c *ZoneCreateCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
+	ZoneSettings `embed:""`
+}
 
-	apiKey, err := getAPIKey()
+func (c *ZoneCreateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	records, err := renderTemplates(c.Name, c.Template, c.Var)
 	if err != nil {
-		return err
+		return &ExitError{Code: CodeError, Err: err}
 	}
 
-	client := api.NewClient(apiKey)
 	req := api.ZoneRequest{
-		Name: c.Name,
-		TTL:  c.TTL,
+		Name:    c.Name,
+		TTL:     c.TTL,
+		Records: records,
+	}
+	c.apply(&req)
+	if err := validateZoneRequest(&req); err != nil {
+		return &ExitError{Code: CodeError, Err: err}
 	}
 
 	id, err := client.CreateZone(ctx, &req)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
 
 	if flags.JSON {
-		return f.Output(map[string]any{"id": id, "name": c.Name}, nil, nil)
+		return f.Output(map[string]any{"id": id, "name": c.Name, "records": len(records)}, nil, nil)
 	}
 
+	if len(records) > 0 {
+		fmt.Printf("Zone created with ID %d (%d records from %s).\n", id, len(records), strings.Join(c.Template, ", "))
+		return nil
+	}
 	fmt.Printf("Zone created with ID %d.\n", id)
 	return nil
 }
@@ -161,42 +194,59 @@ func (c *ZoneCreateCmd) Run(flags *RootFlags) error {
 type ZoneUpdateCmd struct {
 	ID  int `arg:"" help:"Zone ID"`
 	TTL int `help:"Default TTL"`
-}
 
-func (c *ZoneUpdateCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
+	ZoneSettings `embed:""`
+}
 
-	client := api.NewClient(apiKey)
+func (c *ZoneUpdateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	req := api.ZoneRequest{
 		TTL: c.TTL,
 	}
+	c.apply(&req)
+	if err := validateZoneRequest(&req); err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
 
 	if err := client.UpdateZone(ctx, c.ID, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Zone %d updated.\n", c.ID)
 	return nil
 }
 
+// validateZoneRequest checks zone settings before they are sent to the API.
+// Zero values mean "unchanged" and are not validated.
+func validateZoneRequest(req *api.ZoneRequest) error {
+	for _, v := range []struct {
+		name  string
+		value int
+	}{
+		{"ttl", req.TTL},
+		{"refresh", req.Refresh},
+		{"retry", req.Retry},
+		{"expire", req.Expire},
+	} {
+		if v.value < 0 {
+			return fmt.Errorf("--%s must not be negative", v.name)
+		}
+	}
+	if req.Refresh > 0 && req.Retry > 0 && req.Retry >= req.Refresh {
+		return fmt.Errorf("--retry (%d) must be lower than --refresh (%d)", req.Retry, req.Refresh)
+	}
+	if req.Refresh > 0 && req.Expire > 0 && req.Expire <= req.Refresh {
+		return fmt.Errorf("--expire (%d) must be greater than --refresh (%d)", req.Expire, req.Refresh)
+	}
+
+	return nil
+}
+
 // ZoneDeleteCmd deletes a zone.
 type ZoneDeleteCmd struct {
 	ID int `arg:"" help:"Zone ID to delete"`
 }
 
-func (c *ZoneDeleteCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ZoneDeleteCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	if !flags.Yes {
 		fmt.Printf("Delete zone %d? This cannot be undone. [y/N]: ", c.ID)
 		var response string
@@ -207,9 +257,8 @@ func (c *ZoneDeleteCmd) Run(flags *RootFlags) error {
 		}
 	}
 
-	client := api.NewClient(apiKey)
 	if err := client.DeleteZone(ctx, c.ID); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Zone %d deleted.\n", c.ID)
@@ -233,19 +282,10 @@ type ZoneRecordAddCmd struct {
 	Priority int    `help:"Priority (for MX/SRV)" default:"0"`
 }
 
-func (c *ZoneRecordAddCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
-
+func (c *ZoneRecordAddCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	zone, err := client.GetZone(ctx, c.ZoneID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	newRecord := api.DNSRecord{
@@ -259,7 +299,7 @@ func (c *ZoneRecordAddCmd) Run(_ *RootFlags) error {
 
 	req := api.ZoneRequest{Records: zone.Records}
 	if err := client.UpdateZone(ctx, c.ZoneID, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Record %s %s added to zone %d.\n", c.Type, c.Name, c.ZoneID)
@@ -277,19 +317,10 @@ type ZoneRecordUpdateCmd struct {
 	Priority   int    `help:"New priority (for MX/SRV)" default:"-1"`
 }
 
-func (c *ZoneRecordUpdateCmd) Run(_ *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
-
+func (c *ZoneRecordUpdateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	zone, err := client.GetZone(ctx, c.ZoneID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	if len(zone.Records) == 0 {
@@ -323,7 +354,7 @@ func (c *ZoneRecordUpdateCmd) Run(_ *RootFlags) error {
 
 	req := api.ZoneRequest{Records: zone.Records}
 	if err := client.UpdateZone(ctx, c.ZoneID, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Updated %s %s: %s → %s\n", typ, c.Name, old.Content, c.Content)
@@ -338,19 +369,10 @@ type ZoneRecordDeleteCmd struct {
 	Content string `help:"Record content (for disambiguation when multiple records match)"`
 }
 
-func (c *ZoneRecordDeleteCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
-	client := api.NewClient(apiKey)
-
+func (c *ZoneRecordDeleteCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	zone, err := client.GetZone(ctx, c.ZoneID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	if len(zone.Records) == 0 {
@@ -389,7 +411,7 @@ func (c *ZoneRecordDeleteCmd) Run(flags *RootFlags) error {
 
 	req := api.ZoneRequest{Records: zone.Records}
 	if err := client.UpdateZone(ctx, c.ZoneID, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Deleted %s %s → %s\n", typ, c.Name, record.Content)
@@ -429,14 +451,7 @@ type ZoneSyncRecord struct {
 	Priority int    `yaml:"priority"`
 }
 
-func (c *ZoneSyncCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	apiKey, err := getAPIKey()
-	if err != nil {
-		return err
-	}
-
+func (c *ZoneSyncCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
 	data, err := os.ReadFile(c.File)
 	if err != nil {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("read file: %w", err)}
@@ -447,11 +462,9 @@ func (c *ZoneSyncCmd) Run(flags *RootFlags) error {
 		return &ExitError{Code: CodeError, Err: fmt.Errorf("parse YAML: %w", err)}
 	}
 
-	client := api.NewClient(apiKey)
-
 	zone, err := client.GetZone(ctx, c.ZoneID)
 	if err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	newRecords := make([]api.DNSRecord, 0, len(syncFile.Records))
@@ -484,7 +497,7 @@ func (c *ZoneSyncCmd) Run(flags *RootFlags) error {
 
 	req := api.ZoneRequest{Records: newRecords}
 	if err := client.UpdateZone(ctx, c.ZoneID, &req); err != nil {
-		return &ExitError{Code: CodeAPI, Err: err}
+		return apiExitError(err)
 	}
 
 	fmt.Printf("Zone %d synced with %d records.\n", c.ZoneID, len(newRecords))
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/output"
+	"github.com/dedene/realtime-register-cli/internal/zonetemplate"
+)
+
+// ZoneApplyTemplateCmd applies a template to an existing zone.
+type ZoneApplyTemplateCmd struct {
+	ZoneID   int               `arg:"" help:"Zone ID"`
+	Template string            `arg:"" help:"Template name"`
+	Var      map[string]string `help:"Template variable (key=value)" short:"V"`
+	Replace  bool              `help:"Replace existing records with the same name and type"`
+}
+
+func (c *ZoneApplyTemplateCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	zone, err := client.GetZone(ctx, c.ZoneID)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	records, err := renderTemplates(zone.Name, []string{c.Template}, c.Var)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	merged, added := mergeRecords(zone.Records, records, c.Replace)
+	if err := checkSPF(zone.Name, merged); err != nil {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("%w (use --replace to overwrite the existing record)", err)}
+	}
+	if added == 0 {
+		fmt.Printf("Zone %d (%s) already contains all records from %s.\n", zone.ID, zone.Name, c.Template)
+		return nil
+	}
+
+	fmt.Printf("Zone %d (%s): applying %s adds %d record(s):\n", zone.ID, zone.Name, c.Template, added)
+	for _, r := range merged[len(merged)-added:] {
+		fmt.Printf("  + %s %s %s\n", r.Type, r.Name, r.Content)
+	}
+	if removed := len(zone.Records) - (len(merged) - added); removed > 0 {
+		fmt.Printf("  (%d existing record(s) replaced)\n", removed)
+	}
+
+	if !flags.Yes {
+		fmt.Printf("Apply template? [y/N]: ")
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	req := api.ZoneRequest{Records: merged}
+	if err := client.UpdateZone(ctx, c.ZoneID, &req); err != nil {
+		return apiExitError(err)
+	}
+
+	fmt.Printf("Template %s applied to zone %d.\n", c.Template, c.ZoneID)
+	return nil
+}
+
+// ZoneTemplatesCmd lists templates or shows a single template.
+type ZoneTemplatesCmd struct {
+	Name string `arg:"" optional:"" help:"Template name to show"`
+}
+
+func (c *ZoneTemplatesCmd) Run(flags *RootFlags) error {
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if c.Name == "" {
+		templates, err := zonetemplate.List()
+		if err != nil {
+			return &ExitError{Code: CodeError, Err: err}
+		}
+
+		headers := []string{"NAME", "SOURCE", "RECORDS", "DESCRIPTION"}
+		rows := make([][]string, 0, len(templates))
+		for _, t := range templates {
+			rows = append(rows, []string{
+				t.Name,
+				t.Source,
+				fmt.Sprintf("%d", len(t.Records)),
+				t.Description,
+			})
+		}
+		return f.Output(templates, headers, rows)
+	}
+
+	t, err := zonetemplate.Load(c.Name)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	if flags.JSON {
+		return f.Output(t, nil, nil)
+	}
+
+	kvPairs := [][2]string{
+		{"Name", t.Name},
+		{"Source", t.Source},
+		{"Description", t.Description},
+	}
+	if err := f.OutputSingle(t, kvPairs); err != nil {
+		return err
+	}
+
+	if len(t.Variables) > 0 {
+		fmt.Println()
+		fmt.Println("Variables:")
+		headers := []string{"NAME", "REQUIRED", "DEFAULT", "DESCRIPTION"}
+		rows := make([][]string, 0, len(t.Variables))
+		for _, v := range t.Variables {
+			required := "no"
+			if v.Required {
+				required = "yes"
+			}
+			rows = append(rows, []string{v.Name, required, v.Default, v.Description})
+		}
+		if err := output.RenderTable(os.Stdout, headers, rows, f.Colors); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Records:")
+	headers := []string{"TYPE", "NAME", "CONTENT", "WHEN"}
+	rows := make([][]string, 0, len(t.Records))
+	for _, r := range t.Records {
+		rows = append(rows, []string{strings.ToUpper(r.Type), r.Name, r.Content, r.When})
+	}
+	return output.RenderTable(os.Stdout, headers, rows, f.Colors)
+}
+
+// renderTemplates loads and renders templates in order for a zone.
+func renderTemplates(zoneName string, names []string, vars map[string]string) ([]api.DNSRecord, error) {
+	var records []api.DNSRecord
+	for _, name := range names {
+		t, err := zonetemplate.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := t.Render(zoneName, vars)
+		if err != nil {
+			return nil, err
+		}
+		records, _ = mergeRecords(records, rendered, false)
+	}
+	if err := checkSPF(zoneName, records); err != nil {
+		return nil, fmt.Errorf("%w; apply spf-dmarc alone with --var spf=... listing every sender", err)
+	}
+	return records, nil
+}
+
+// checkSPF fails if a name would have more than one SPF record, which
+// receivers treat as a permanent error and so reject all SPF checks.
+func checkSPF(zoneName string, records []api.DNSRecord) error {
+	seen := make(map[string]string)
+	for _, r := range records {
+		if !strings.EqualFold(r.Type, "TXT") || !isSPF(r.Content) {
+			continue
+		}
+		name := recordOwner(zoneName, r.Name)
+		if prev, ok := seen[name]; ok {
+			return fmt.Errorf("two SPF records for %s (%q and %q); a name may only have one", r.Name, prev, r.Content)
+		}
+		seen[name] = r.Content
+	}
+	return nil
+}
+
+// isSPF reports whether TXT content is an SPF policy.
+func isSPF(content string) bool {
+	content = strings.ToLower(strings.Trim(strings.TrimSpace(content), `"`))
+	return content == "v=spf1" || strings.HasPrefix(content, "v=spf1 ")
+}
+
+// recordOwner normalises a record name, so that "@", "" and the zone name
+// all mean the apex.
+func recordOwner(zoneName, name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zoneName = strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	switch {
+	case name == "@" || name == "" || name == zoneName:
+		return ""
+	case strings.HasSuffix(name, "."+zoneName):
+		return strings.TrimSuffix(name, "."+zoneName)
+	}
+	return name
+}
+
+// mergeRecords adds records to existing, skipping exact duplicates.
+// With replace, existing records sharing a name and type with an added record are dropped.
+// Returns the merged records and the number of records added.
+func mergeRecords(existing, add []api.DNSRecord, replace bool) ([]api.DNSRecord, int) {
+	merged := make([]api.DNSRecord, 0, len(existing)+len(add))
+
+	if replace {
+		replaced := make(map[string]bool, len(add))
+		for _, r := range add {
+			replaced[recordKey(r.Type, r.Name)] = true
+		}
+		for _, r := range existing {
+			if !replaced[recordKey(r.Type, r.Name)] {
+				merged = append(merged, r)
+			}
+		}
+	} else {
+		merged = append(merged, existing...)
+	}
+
+	added := 0
+	for _, r := range add {
+		if len(findRecords(merged, r.Type, r.Name, r.Content)) > 0 {
+			continue
+		}
+		merged = append(merged, r)
+		added++
+	}
+
+	return merged, added
+}
+
+func recordKey(typ, name string) string {
+	return strings.ToUpper(typ) + " " + name
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
@@ -69,3 +70,78 @@ func TestFindRecords_ReturnsCorrectIndices(t *testing.T) {
 		t.Errorf("expected index 2, got %d", indices[0])
 	}
 }
+
+func TestMergeRecords(t *testing.T) {
+	existing := []api.DNSRecord{
+		{Type: "A", Name: "@", Content: "1.2.3.4"},
+		{Type: "MX", Name: "@", Content: "old.example.com", Prio: 10},
+	}
+	add := []api.DNSRecord{
+		{Type: "A", Name: "@", Content: "1.2.3.4"},
+		{Type: "MX", Name: "@", Content: "smtp.google.com", Prio: 1},
+	}
+
+	merged, added := mergeRecords(existing, add, false)
+	if added != 1 {
+		t.Errorf("mergeRecords() added = %d, want 1 (duplicate A skipped)", added)
+	}
+	if len(merged) != 3 {
+		t.Errorf("mergeRecords() = %d records, want 3", len(merged))
+	}
+
+	merged, _ = mergeRecords(existing, add, true)
+	if len(merged) != 2 {
+		t.Fatalf("mergeRecords(replace) = %d records, want 2", len(merged))
+	}
+	if got := findRecords(merged, "MX", "@", "old.example.com"); len(got) != 0 {
+		t.Error("mergeRecords(replace) kept the old MX record")
+	}
+}
+
+func TestCheckSPF(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // built-in templates only
+
+	records, err := renderTemplates("example.com", []string{"spf-dmarc", "google-workspace"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "two SPF records") {
+		t.Errorf("renderTemplates(spf-dmarc, google-workspace) = %d records, err %v; want an SPF conflict", len(records), err)
+	}
+
+	existing := []api.DNSRecord{{Type: "TXT", Name: "example.com", Content: `"v=spf1 mx -all"`}}
+	add := []api.DNSRecord{
+		{Type: "TXT", Name: "@", Content: "v=spf1 include:_spf.google.com ~all"},
+		{Type: "TXT", Name: "mail", Content: "v=spf1 a -all"},
+		{Type: "TXT", Name: "@", Content: "google-site-verification=abc"},
+	}
+	merged, _ := mergeRecords(existing, add, false)
+	if err := checkSPF("example.com", merged); err == nil {
+		t.Error("checkSPF() = nil for two apex SPF records")
+	}
+	existing[0].Name = "@"
+	merged, _ = mergeRecords(existing, add, true)
+	if err := checkSPF("example.com", merged); err != nil {
+		t.Errorf("checkSPF() after replace = %v", err)
+	}
+}
+
+func TestValidateZoneRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     api.ZoneRequest
+		wantErr bool
+	}{
+		{"empty update", api.ZoneRequest{}, false},
+		{"valid SOA timers", api.ZoneRequest{Refresh: 3600, Retry: 600, Expire: 604800}, false},
+		{"retry above refresh", api.ZoneRequest{Refresh: 600, Retry: 3600}, true},
+		{"expire below refresh", api.ZoneRequest{Refresh: 3600, Expire: 1800}, true},
+		{"negative ttl", api.ZoneRequest{TTL: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateZoneRequest(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateZoneRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/dnscheck"
+	"github.com/dedene/realtime-register-cli/internal/output"
+)
+
+// ZoneVerifyCmd compares zone records with what the authoritative nameservers serve.
+type ZoneVerifyCmd struct {
+	ZoneID       int           `arg:"" help:"Zone ID"`
+	NS           []string      `help:"Nameservers to query (default: the domain's nameservers)"`
+	QueryTimeout time.Duration `help:"Timeout for each DNS query" default:"5s"`
+}
+
+func (c *ZoneVerifyCmd) Run(ctx context.Context, flags *RootFlags, client *api.Client) error {
+	zone, err := client.GetZone(ctx, c.ZoneID)
+	if err != nil {
+		return apiExitError(err)
+	}
+
+	nameservers, err := zoneNameservers(ctx, client, zone.Name, c.NS)
+	if err != nil {
+		return err
+	}
+
+	dc := dnscheck.NewClient()
+	dc.Timeout = c.QueryTimeout
+
+	servers, err := dc.ResolveServers(ctx, nameservers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if len(servers) == 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("no nameservers for %s could be resolved", zone.Name)}
+	}
+
+	report, err := dc.VerifyZone(ctx, zone.Name, zone.Records, servers)
+	if err != nil {
+		return &ExitError{Code: CodeError, Err: err}
+	}
+
+	f := output.NewFormatter(os.Stdout, flags.JSON, flags.Plain, flags.Color == "never")
+
+	if flags.JSON {
+		if err := f.Output(report, nil, nil); err != nil {
+			return err
+		}
+	} else if err := renderVerifyReport(f, report); err != nil {
+		return err
+	}
+
+	if n := len(report.Findings); n > 0 {
+		return &ExitError{Code: CodeError, Err: fmt.Errorf("zone %s: %d discrepancies found", zone.Name, n)}
+	}
+	if f.Mode == output.ModeTable {
+		fmt.Println(f.Colors.Green("All records are served as expected."))
+	}
+	return nil
+}
+
+func renderVerifyReport(f *output.Formatter, report *dnscheck.VerifyReport) error {
+	headers := []string{"SERVER", "NAME", "TYPE", "ISSUE", "EXPECTED", "ACTUAL"}
+	rows := make([][]string, 0, len(report.Findings))
+	for _, fd := range report.Findings {
+		kind := fd.Kind
+		if f.Mode == output.ModeTable {
+			kind = findingColor(f.Colors, kind)
+		}
+		rows = append(rows, []string{
+			fd.Server,
+			strings.TrimSuffix(fd.Name, "."),
+			fd.Type,
+			kind,
+			fd.Expected,
+			fd.Actual,
+		})
+	}
+	if len(rows) > 0 {
+		if err := f.Output(report.Findings, headers, rows); err != nil {
+			return err
+		}
+	}
+	if f.Mode != output.ModeTable {
+		return nil
+	}
+
+	if len(rows) > 0 {
+		fmt.Println()
+	}
+	names := make([]string, 0, len(report.Servers))
+	for _, s := range report.Servers {
+		names = append(names, s.Name)
+	}
+	fmt.Printf("Checked %d record set(s) on %s.\n", report.Checked, strings.Join(names, ", "))
+	if len(report.Skipped) > 0 {
+		fmt.Printf("Skipped unsupported record types: %s\n", strings.Join(report.Skipped, ", "))
+	}
+	return nil
+}
+
+// zoneNameservers returns the nameservers to query for a zone: explicit flags,
+// then the registered domain's nameservers, then a public NS lookup.
+func zoneNameservers(ctx context.Context, client *api.Client, zoneName string, explicit []string) ([]string, error) {
+	if len(explicit) > 0 {
+		return explicit, nil
+	}
+
+	domain, err := client.GetDomain(ctx, zoneName)
+	if err == nil && len(domain.NameServers) > 0 {
+		return domain.NameServers, nil
+	}
+	var notFound *api.NotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, apiExitError(err)
+	}
+
+	records, err := net.DefaultResolver.LookupNS(ctx, zoneName)
+	if err != nil || len(records) == 0 {
+		return nil, &ExitError{Code: CodeError, Err: fmt.Errorf("no nameservers found for %s; use --ns", zoneName)}
+	}
+	hosts := make([]string, 0, len(records))
+	for _, r := range records {
+		hosts = append(hosts, r.Host)
+	}
+	return hosts, nil
+}
+
+func findingColor(colors *output.Colors, kind string) string {
+	switch kind {
+	case dnscheck.FindingMissing, dnscheck.FindingError:
+		return colors.Red(kind)
+	case dnscheck.FindingExtra, dnscheck.FindingStale:
+		return colors.Yellow(kind)
+	default:
+		return kind
+	}
+}
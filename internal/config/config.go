@@ -13,6 +13,13 @@ type File struct {
 	DefaultTLDs    []string `yaml:"default_tlds,omitempty"`
 	AutoRenew      *bool    `yaml:"auto_renew,omitempty"`
 	KeyringBackend string   `yaml:"keyring_backend,omitempty"`
+	Endpoint       string   `yaml:"endpoint,omitempty"`
+	HTTPTimeout    string   `yaml:"http_timeout,omitempty"`
+	Retries        *int     `yaml:"retries,omitempty"`
+	RetryBackoff   string   `yaml:"retry_backoff,omitempty"`
+	RateLimit      float64  `yaml:"rate_limit,omitempty"`
+
+	Register *RegisterDefaults `yaml:"register,omitempty"`
 }
 
 // ConfigExists returns true if the config file exists.
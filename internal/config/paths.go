@@ -58,3 +58,21 @@ func EnsureKeyringDir() (string, error) {
 	}
 	return dir, nil
 }
+
+// CacheDir returns the path to the API response cache directory.
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// TemplatesDir returns the path to the user zone template directory.
+func TemplatesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "templates"), nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RegisterDefaults holds defaults for domain registration. Entries under TLDs
+// override the top-level values for domains in that TLD.
+type RegisterDefaults struct {
+	Registrant  string                       `yaml:"registrant,omitempty"`
+	Admin       string                       `yaml:"admin,omitempty"`
+	Tech        string                       `yaml:"tech,omitempty"`
+	Billing     string                       `yaml:"billing,omitempty"`
+	Nameservers []string                     `yaml:"ns,omitempty"`
+	Period      int                          `yaml:"period,omitempty"`
+	Privacy     *bool                        `yaml:"privacy,omitempty"`
+	AutoRenew   *bool                        `yaml:"auto_renew,omitempty"`
+	TLDs        map[string]*RegisterDefaults `yaml:"tlds,omitempty"`
+}
+
+// ForTLD returns the defaults for tld: the per-TLD entry layered over the
+// top-level values. d may be nil.
+func (d *RegisterDefaults) ForTLD(tld string) RegisterDefaults {
+	if d == nil {
+		return RegisterDefaults{}
+	}
+	out := *d
+	out.TLDs = nil
+
+	o := d.TLDs[strings.TrimPrefix(strings.ToLower(tld), ".")]
+	if o == nil {
+		return out
+	}
+	if o.Registrant != "" {
+		out.Registrant = o.Registrant
+	}
+	if o.Admin != "" {
+		out.Admin = o.Admin
+	}
+	if o.Tech != "" {
+		out.Tech = o.Tech
+	}
+	if o.Billing != "" {
+		out.Billing = o.Billing
+	}
+	if len(o.Nameservers) > 0 {
+		out.Nameservers = o.Nameservers
+	}
+	if o.Period != 0 {
+		out.Period = o.Period
+	}
+	if o.Privacy != nil {
+		out.Privacy = o.Privacy
+	}
+	if o.AutoRenew != nil {
+		out.AutoRenew = o.AutoRenew
+	}
+	return out
+}
+
+// RegisterKeys lists the settable register.* fields.
+var RegisterKeys = []string{"registrant", "admin", "tech", "billing", "ns", "period", "privacy", "auto_renew"}
+
+// Get returns a field by its config key name.
+func (d *RegisterDefaults) Get(field string) (string, error) {
+	switch field {
+	case "registrant":
+		return d.Registrant, nil
+	case "admin":
+		return d.Admin, nil
+	case "tech":
+		return d.Tech, nil
+	case "billing":
+		return d.Billing, nil
+	case "ns":
+		return strings.Join(d.Nameservers, ","), nil
+	case "period":
+		if d.Period == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(d.Period), nil
+	case "privacy":
+		return formatBool(d.Privacy), nil
+	case "auto_renew":
+		return formatBool(d.AutoRenew), nil
+	default:
+		return "", fmt.Errorf("unknown register setting: %s (valid: %s)", field, strings.Join(RegisterKeys, ", "))
+	}
+}
+
+// Set updates a field by its config key name. An empty value clears it.
+func (d *RegisterDefaults) Set(field, value string) error {
+	switch field {
+	case "registrant":
+		d.Registrant = value
+	case "admin":
+		d.Admin = value
+	case "tech":
+		d.Tech = value
+	case "billing":
+		d.Billing = value
+	case "ns":
+		d.Nameservers = nil
+		if value != "" {
+			d.Nameservers = strings.Split(value, ",")
+		}
+	case "period":
+		if value == "" {
+			d.Period = 0
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 10 {
+			return fmt.Errorf("period must be 1-10 years")
+		}
+		d.Period = n
+	case "privacy":
+		return parseBool(value, &d.Privacy)
+	case "auto_renew":
+		return parseBool(value, &d.AutoRenew)
+	default:
+		return fmt.Errorf("unknown register setting: %s (valid: %s)", field, strings.Join(RegisterKeys, ", "))
+	}
+	return nil
+}
+
+func formatBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func parseBool(value string, dst **bool) error {
+	if value == "" {
+		*dst = nil
+		return nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*dst = &b
+	return nil
+}
@@ -0,0 +1,270 @@
+package dnscheck
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+	maxUDPSize     = 4096
+)
+
+// Client sends DNS queries directly to specific nameservers.
+type Client struct {
+	Timeout time.Duration
+
+	// Lookup resolves a nameserver hostname to host:port addresses.
+	// Defaults to the system resolver on port 53.
+	Lookup func(ctx context.Context, host string) ([]string, error)
+
+	// ParentServers returns the servers for the zone above domain.
+	// Defaults to an NS lookup of the parent zone via the system resolver.
+	ParentServers func(ctx context.Context, domain string) ([]Server, error)
+}
+
+// NewClient creates a Client with a 5s per-query timeout.
+func NewClient() *Client {
+	return &Client{Timeout: defaultTimeout}
+}
+
+// Server is a nameserver with its resolved address (host:port).
+type Server struct {
+	Name string `json:"name"`
+	Addr string `json:"addr"`
+}
+
+// RR is a simplified resource record from a DNS answer.
+type RR struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	TTL   uint32 `json:"ttl"`
+	Value string `json:"value"`
+}
+
+// Exchange sends a non-recursive query to server and returns the response.
+// Truncated UDP responses are retried over TCP.
+func (c *Client) Exchange(ctx context.Context, server, name string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	qname, err := dnsmessage.NewName(Fqdn(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+
+	id := uint16(rand.N(1 << 16)) //nolint:gosec // G404: query IDs need not be cryptographically random
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack query: %w", err)
+	}
+
+	resp, err := c.exchange(ctx, "udp", server, packed)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Truncated {
+		resp, err = c.exchange(ctx, "tcp", server, packed)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.ID != id {
+		return nil, fmt.Errorf("query %s %s: response ID mismatch", name, qtype)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) exchange(ctx context.Context, network, server string, packed []byte) (*dnsmessage.Message, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", server, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var buf []byte
+	if network == "tcp" {
+		msg := make([]byte, 2+len(packed))
+		binary.BigEndian.PutUint16(msg, uint16(len(packed))) //nolint:gosec // G115: DNS queries are far below 64KiB
+		copy(msg[2:], packed)
+		if _, err := conn.Write(msg); err != nil {
+			return nil, fmt.Errorf("send query to %s: %w", server, err)
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, fmt.Errorf("read response from %s: %w", server, err)
+		}
+		buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, fmt.Errorf("read response from %s: %w", server, err)
+		}
+	} else {
+		if _, err := conn.Write(packed); err != nil {
+			return nil, fmt.Errorf("send query to %s: %w", server, err)
+		}
+		buf = make([]byte, maxUDPSize)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("read response from %s: %w", server, err)
+		}
+		buf = buf[:n]
+	}
+
+	var resp dnsmessage.Message
+	if err := resp.Unpack(buf); err != nil {
+		return nil, fmt.Errorf("parse response from %s: %w", server, err)
+	}
+	return &resp, nil
+}
+
+// ResolveServers resolves nameserver hostnames to query addresses.
+// Entries that are already IP addresses (optionally with a port) are used as-is.
+func (c *Client) ResolveServers(ctx context.Context, hosts []string) ([]Server, error) {
+	servers := make([]Server, 0, len(hosts))
+	var errs []error
+	for _, h := range hosts {
+		name := strings.TrimSuffix(h, ".")
+		addrs, err := c.lookup(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resolve nameserver %s: %w", name, err))
+			continue
+		}
+		servers = append(servers, Server{Name: name, Addr: addrs[0]})
+	}
+	return servers, errors.Join(errs...)
+}
+
+// lookup returns host:port query addresses for a nameserver.
+func (c *Client) lookup(ctx context.Context, host string) ([]string, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil && net.ParseIP(h) != nil {
+		return []string{host}, nil
+	}
+	if net.ParseIP(host) != nil {
+		return []string{net.JoinHostPort(host, "53")}, nil
+	}
+
+	if c.Lookup != nil {
+		return c.Lookup(ctx, host)
+	}
+
+	ips, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no addresses")
+	}
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.JoinHostPort(ip, "53"))
+	}
+	return addrs, nil
+}
+
+// Answers extracts records of qtype owned by name from a response.
+func Answers(resp *dnsmessage.Message, name string, qtype dnsmessage.Type) []RR {
+	owner := Fqdn(name)
+	var rrs []RR
+	for _, r := range resp.Answers {
+		if r.Header.Type != qtype || !strings.EqualFold(r.Header.Name.String(), owner) {
+			continue
+		}
+		if rr, ok := toRR(r); ok {
+			rrs = append(rrs, rr)
+		}
+	}
+	return rrs
+}
+
+// toRR converts a resource to an RR with a normalised value.
+func toRR(r dnsmessage.Resource) (RR, bool) {
+	rr := RR{
+		Name: strings.ToLower(r.Header.Name.String()),
+		Type: TypeString(r.Header.Type),
+		TTL:  r.Header.TTL,
+	}
+
+	switch b := r.Body.(type) {
+	case *dnsmessage.AResource:
+		rr.Value = net.IP(b.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		rr.Value = net.IP(b.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		rr.Value = strings.ToLower(b.CNAME.String())
+	case *dnsmessage.NSResource:
+		rr.Value = strings.ToLower(b.NS.String())
+	case *dnsmessage.PTRResource:
+		rr.Value = strings.ToLower(b.PTR.String())
+	case *dnsmessage.MXResource:
+		rr.Value = fmt.Sprintf("%d %s", b.Pref, strings.ToLower(b.MX.String()))
+	case *dnsmessage.TXTResource:
+		rr.Value = strings.Join(b.TXT, "")
+	case *dnsmessage.SRVResource:
+		rr.Value = fmt.Sprintf("%d %d %d %s", b.Priority, b.Weight, b.Port, strings.ToLower(b.Target.String()))
+	case *dnsmessage.SOAResource:
+		rr.Value = fmt.Sprintf("%s %s %d", strings.ToLower(b.NS.String()), strings.ToLower(b.MBox.String()), b.Serial)
+	default:
+		return RR{}, false
+	}
+	return rr, true
+}
+
+// SOASerial returns the serial of the first SOA record in the answer section.
+func SOASerial(resp *dnsmessage.Message) (uint32, bool) {
+	for _, r := range resp.Answers {
+		if soa, ok := r.Body.(*dnsmessage.SOAResource); ok {
+			return soa.Serial, true
+		}
+	}
+	return 0, false
+}
+
+// queryTypes maps supported record type names to DNS types.
+var queryTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"PTR":   dnsmessage.TypePTR,
+	"SOA":   dnsmessage.TypeSOA,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// TypeString returns the record type name (e.g. "MX") for a DNS type.
+func TypeString(t dnsmessage.Type) string {
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+// Fqdn returns name lowercased with a trailing dot.
+func Fqdn(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
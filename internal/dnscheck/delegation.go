@@ -0,0 +1,258 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// NSStatus is the result of checking a single delegated nameserver.
+type NSStatus struct {
+	Name          string   `json:"name"`
+	Addresses     []string `json:"addresses,omitempty"`
+	Authoritative bool     `json:"authoritative"`
+	Serial        uint32   `json:"serial,omitempty"`
+	NS            []string `json:"ns,omitempty"`
+	Lame          bool     `json:"lame"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// DelegationReport summarises a nameserver delegation health check.
+type DelegationReport struct {
+	Domain     string     `json:"domain"`
+	Registered []string   `json:"registered"`
+	Parent     []string   `json:"parent"`
+	Child      []string   `json:"child"`
+	Servers    []NSStatus `json:"servers"`
+	Issues     []string   `json:"issues"`
+}
+
+// Healthy returns true if no issues were found.
+func (r *DelegationReport) Healthy() bool {
+	return len(r.Issues) == 0
+}
+
+// CheckDelegation checks that each registered nameserver resolves and answers
+// authoritatively for domain, that the NS set at the parent matches the child
+// zone, and that all servers serve the same SOA serial.
+func (c *Client) CheckDelegation(ctx context.Context, domain string, nameservers []string) (*DelegationReport, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	report := &DelegationReport{
+		Domain:     domain,
+		Registered: normalizeHosts(nameservers),
+		Issues:     []string{},
+	}
+
+	if len(report.Registered) == 0 {
+		report.Issues = append(report.Issues, "no nameservers registered for the domain")
+		return report, nil
+	}
+
+	childNS := make(map[string]bool)
+	serials := make(map[uint32][]string)
+
+	for _, host := range report.Registered {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		status := c.checkNameserver(ctx, domain, host)
+		report.Servers = append(report.Servers, status)
+
+		if status.Lame {
+			report.Issues = append(report.Issues, fmt.Sprintf("lame delegation: %s %s", host, status.Error))
+			continue
+		}
+		serials[status.Serial] = append(serials[status.Serial], host)
+		for _, ns := range status.NS {
+			childNS[ns] = true
+		}
+	}
+
+	report.Child = sortedKeys(childNS)
+
+	if len(serials) > 1 {
+		parts := make([]string, 0, len(serials))
+		for serial, hosts := range serials {
+			parts = append(parts, fmt.Sprintf("%d on %s", serial, strings.Join(hosts, ", ")))
+		}
+		sort.Strings(parts)
+		report.Issues = append(report.Issues, "SOA serial mismatch: "+strings.Join(parts, "; "))
+	}
+
+	parent, err := c.parentNS(ctx, domain)
+	if err != nil {
+		report.Issues = append(report.Issues, fmt.Sprintf("parent NS lookup failed: %v", err))
+	} else {
+		report.Parent = parent
+		if diff := diffSets(report.Parent, report.Registered); diff != "" {
+			report.Issues = append(report.Issues, "parent NS set differs from registered nameservers: "+diff)
+		}
+	}
+
+	if len(report.Child) > 0 {
+		want := report.Parent
+		if len(want) == 0 {
+			want = report.Registered
+		}
+		if diff := diffSets(want, report.Child); diff != "" {
+			report.Issues = append(report.Issues, "child zone NS set differs from parent: "+diff)
+		}
+	}
+
+	return report, nil
+}
+
+// checkNameserver resolves host and queries it for the domain's SOA and NS records.
+func (c *Client) checkNameserver(ctx context.Context, domain, host string) NSStatus {
+	status := NSStatus{Name: host}
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil {
+		status.Lame = true
+		status.Error = fmt.Sprintf("does not resolve: %v", err)
+		return status
+	}
+	status.Addresses = addrs
+
+	resp, err := c.Exchange(ctx, addrs[0], domain, dnsmessage.TypeSOA)
+	if err != nil {
+		status.Lame = true
+		status.Error = "does not respond: " + err.Error()
+		return status
+	}
+	if resp.RCode != dnsmessage.RCodeSuccess {
+		status.Lame = true
+		status.Error = "answered " + resp.RCode.String()
+		return status
+	}
+	status.Authoritative = resp.Authoritative
+	if !resp.Authoritative {
+		status.Lame = true
+		status.Error = "is not authoritative"
+		return status
+	}
+	serial, ok := SOASerial(resp)
+	if !ok {
+		status.Lame = true
+		status.Error = "returned no SOA record"
+		return status
+	}
+	status.Serial = serial
+
+	resp, err = c.Exchange(ctx, addrs[0], domain, dnsmessage.TypeNS)
+	if err == nil {
+		for _, rr := range Answers(resp, domain, dnsmessage.TypeNS) {
+			status.NS = append(status.NS, strings.TrimSuffix(rr.Value, "."))
+		}
+		sort.Strings(status.NS)
+	}
+
+	return status
+}
+
+// parentNS returns the NS set for domain as delegated by the parent zone.
+func (c *Client) parentNS(ctx context.Context, domain string) ([]string, error) {
+	servers, err := c.parentServers(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, srv := range servers {
+		resp, err := c.Exchange(ctx, srv.Addr, domain, dnsmessage.TypeNS)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// A referral carries the delegation in the authority section.
+		set := make(map[string]bool)
+		for _, sec := range [][]dnsmessage.Resource{resp.Answers, resp.Authorities} {
+			for _, r := range sec {
+				ns, ok := r.Body.(*dnsmessage.NSResource)
+				if ok && strings.EqualFold(r.Header.Name.String(), Fqdn(domain)) {
+					set[strings.TrimSuffix(strings.ToLower(ns.NS.String()), ".")] = true
+				}
+			}
+		}
+		if len(set) > 0 {
+			return sortedKeys(set), nil
+		}
+		errs = append(errs, fmt.Errorf("%s returned no delegation for %s", srv.Name, domain))
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *Client) parentServers(ctx context.Context, domain string) ([]Server, error) {
+	if c.ParentServers != nil {
+		return c.ParentServers(ctx, domain)
+	}
+
+	idx := strings.Index(domain, ".")
+	if idx < 0 {
+		return nil, fmt.Errorf("%s has no parent zone", domain)
+	}
+	parent := domain[idx+1:]
+
+	records, err := net.DefaultResolver.LookupNS(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("lookup NS for %s: %w", parent, err)
+	}
+	hosts := make([]string, 0, len(records))
+	for _, r := range records {
+		hosts = append(hosts, r.Host)
+	}
+	servers, err := c.ResolveServers(ctx, hosts)
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("resolve %s nameservers: %w", parent, err)
+	}
+	return servers, nil
+}
+
+// diffSets describes the difference between two host sets, or "" if equal.
+func diffSets(want, got []string) string {
+	wantSet := make(map[string]bool, len(want))
+	for _, h := range want {
+		wantSet[h] = true
+	}
+	gotSet := make(map[string]bool, len(got))
+	for _, h := range got {
+		gotSet[h] = true
+	}
+
+	var missing, extra []string
+	for _, h := range want {
+		if !gotSet[h] {
+			missing = append(missing, h)
+		}
+	}
+	for _, h := range got {
+		if !wantSet[h] {
+			extra = append(extra, h)
+		}
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, "extra "+strings.Join(extra, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func normalizeHosts(hosts []string) []string {
+	set := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		if h = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), "."); h != "" {
+			set[h] = true
+		}
+	}
+	return sortedKeys(set)
+}
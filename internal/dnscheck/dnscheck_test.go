@@ -0,0 +1,341 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
+
+// testServer is an in-process UDP DNS server answering from a fixed record set.
+type testServer struct {
+	t    *testing.T
+	conn net.PacketConn
+
+	mu            sync.Mutex
+	records       map[string][]dnsmessage.Resource // "name TYPE" → answers
+	referrals     map[string][]dnsmessage.Resource // "name" → delegation NS records
+	authoritative bool
+	rcode         dnsmessage.RCode
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &testServer{
+		t:             t,
+		conn:          conn,
+		records:       make(map[string][]dnsmessage.Resource),
+		referrals:     make(map[string][]dnsmessage.Resource),
+		authoritative: true,
+	}
+	go s.serve()
+	t.Cleanup(func() { _ = conn.Close() })
+	return s
+}
+
+func (s *testServer) Addr() string { return s.conn.LocalAddr().String() }
+
+func (s *testServer) add(name string, ttl uint32, body dnsmessage.ResourceBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hdr := dnsmessage.ResourceHeader{
+		Name:  dnsmessage.MustNewName(Fqdn(name)),
+		Type:  bodyType(body),
+		Class: dnsmessage.ClassINET,
+		TTL:   ttl,
+	}
+	key := Fqdn(name) + " " + TypeString(hdr.Type)
+	s.records[key] = append(s.records[key], dnsmessage.Resource{Header: hdr, Body: body})
+}
+
+func (s *testServer) refer(name string, ns ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range ns {
+		s.referrals[Fqdn(name)] = append(s.referrals[Fqdn(name)], dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{
+				Name:  dnsmessage.MustNewName(Fqdn(name)),
+				Type:  dnsmessage.TypeNS,
+				Class: dnsmessage.ClassINET,
+				TTL:   172800,
+			},
+			Body: &dnsmessage.NSResource{NS: dnsmessage.MustNewName(Fqdn(n))},
+		})
+	}
+}
+
+func (s *testServer) setAuthoritative(aa bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authoritative = aa
+}
+
+func (s *testServer) setRCode(rcode dnsmessage.RCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rcode = rcode
+}
+
+func bodyType(body dnsmessage.ResourceBody) dnsmessage.Type {
+	switch body.(type) {
+	case *dnsmessage.AResource:
+		return dnsmessage.TypeA
+	case *dnsmessage.AAAAResource:
+		return dnsmessage.TypeAAAA
+	case *dnsmessage.CNAMEResource:
+		return dnsmessage.TypeCNAME
+	case *dnsmessage.MXResource:
+		return dnsmessage.TypeMX
+	case *dnsmessage.NSResource:
+		return dnsmessage.TypeNS
+	case *dnsmessage.TXTResource:
+		return dnsmessage.TypeTXT
+	case *dnsmessage.SOAResource:
+		return dnsmessage.TypeSOA
+	default:
+		return 0
+	}
+}
+
+func (s *testServer) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var req dnsmessage.Message
+		if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+			continue
+		}
+		q := req.Questions[0]
+
+		s.mu.Lock()
+		resp := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: s.authoritative, RCode: s.rcode},
+			Questions: req.Questions,
+		}
+		qname := strings.ToLower(q.Name.String())
+		if refs, ok := s.referrals[qname]; ok {
+			resp.Authoritative = false
+			resp.Authorities = refs
+		} else {
+			resp.Answers = s.records[qname+" "+TypeString(q.Type)]
+		}
+		s.mu.Unlock()
+
+		packed, err := resp.Pack()
+		if err != nil {
+			s.t.Errorf("pack response: %v", err)
+			continue
+		}
+		_, _ = s.conn.WriteTo(packed, addr)
+	}
+}
+
+func ip4(s string) *dnsmessage.AResource {
+	var a dnsmessage.AResource
+	copy(a.A[:], net.ParseIP(s).To4())
+	return &a
+}
+
+func TestVerifyZone(t *testing.T) {
+	srv := newTestServer(t)
+	srv.add("example.com", 3600, ip4("192.0.2.1"))
+	srv.add("example.com", 3600, ip4("192.0.2.99")) // extra
+	srv.add("www.example.com", 300, &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("example.com.")})
+	srv.add("example.com", 3600, &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")})
+	srv.add("example.com", 3600, &dnsmessage.TXTResource{TXT: []string{"v=spf1 mx -all"}})
+
+	records := []api.DNSRecord{
+		{Name: "@", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www", Type: "CNAME", Content: "example.com", TTL: 3600}, // stale TTL
+		{Name: "@", Type: "MX", Content: "mail.example.com", TTL: 3600, Prio: 10},
+		{Name: "@", Type: "TXT", Content: `"v=spf1 mx -all"`, TTL: 3600},
+		{Name: "mail", Type: "A", Content: "192.0.2.25", TTL: 3600}, // missing
+		{Name: "@", Type: "CAA", Content: `0 issue "letsencrypt.org"`, TTL: 3600},
+	}
+
+	c := NewClient()
+	report, err := c.VerifyZone(context.Background(), "example.com", records, []Server{{Name: "ns1", Addr: srv.Addr()}})
+	if err != nil {
+		t.Fatalf("VerifyZone() error = %v", err)
+	}
+
+	if report.Checked != 5 {
+		t.Errorf("Checked = %d, want 5", report.Checked)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0] != "CAA" {
+		t.Errorf("Skipped = %v, want [CAA]", report.Skipped)
+	}
+
+	got := make(map[string]Finding)
+	for _, f := range report.Findings {
+		got[f.Kind+" "+f.Name+" "+f.Type] = f
+	}
+	if len(report.Findings) != 3 {
+		t.Errorf("Findings = %+v, want 3", report.Findings)
+	}
+	if f, ok := got["extra example.com. A"]; !ok || f.Actual != "192.0.2.99" {
+		t.Errorf("missing extra A finding: %+v", report.Findings)
+	}
+	if _, ok := got["missing mail.example.com. A"]; !ok {
+		t.Errorf("missing 'missing' A finding: %+v", report.Findings)
+	}
+	if _, ok := got["stale www.example.com. CNAME"]; !ok {
+		t.Errorf("missing stale CNAME finding: %+v", report.Findings)
+	}
+}
+
+func TestVerifyZone_ServerError(t *testing.T) {
+	srv := newTestServer(t)
+	srv.setRCode(dnsmessage.RCodeRefused)
+
+	c := NewClient()
+	report, err := c.VerifyZone(context.Background(), "example.com",
+		[]api.DNSRecord{{Name: "@", Type: "A", Content: "192.0.2.1"}},
+		[]Server{{Name: "ns1", Addr: srv.Addr()}})
+	if err != nil {
+		t.Fatalf("VerifyZone() error = %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Kind != FindingError {
+		t.Errorf("Findings = %+v, want one error finding", report.Findings)
+	}
+}
+
+func TestOwnerName(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"@", "example.com."},
+		{"", "example.com."},
+		{"www", "www.example.com."},
+		{"WWW", "www.example.com."},
+		{"www.example.com", "www.example.com."},
+		{"www.example.com.", "www.example.com."},
+		{"example.com", "example.com."},
+	}
+	for _, tt := range tests {
+		if got := OwnerName("example.com", tt.name); got != tt.want {
+			t.Errorf("OwnerName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func soa(serial uint32) *dnsmessage.SOAResource {
+	return &dnsmessage.SOAResource{
+		NS:      dnsmessage.MustNewName("ns1.example.net."),
+		MBox:    dnsmessage.MustNewName("hostmaster.example.com."),
+		Serial:  serial,
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  604800,
+		MinTTL:  300,
+	}
+}
+
+func TestCheckDelegation(t *testing.T) {
+	parent := newTestServer(t)
+	parent.refer("example.com", "ns1.example.net", "ns2.example.net")
+
+	ns1 := newTestServer(t)
+	ns1.add("example.com", 3600, soa(2024010101))
+	ns1.add("example.com", 3600, &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.net.")})
+	ns1.add("example.com", 3600, &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns2.example.net.")})
+
+	ns2 := newTestServer(t)
+	ns2.add("example.com", 3600, soa(2024010101))
+	ns2.add("example.com", 3600, &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.net.")})
+	ns2.add("example.com", 3600, &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns2.example.net.")})
+
+	addrs := map[string]string{
+		"ns1.example.net": ns1.Addr(),
+		"ns2.example.net": ns2.Addr(),
+	}
+
+	c := NewClient()
+	c.Lookup = func(_ context.Context, host string) ([]string, error) {
+		if a, ok := addrs[host]; ok {
+			return []string{a}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	c.ParentServers = func(context.Context, string) ([]Server, error) {
+		return []Server{{Name: "a.gtld-servers.test", Addr: parent.Addr()}}, nil
+	}
+
+	report, err := c.CheckDelegation(context.Background(), "example.com", []string{"NS1.example.net.", "ns2.example.net"})
+	if err != nil {
+		t.Fatalf("CheckDelegation() error = %v", err)
+	}
+	if !report.Healthy() {
+		t.Fatalf("CheckDelegation() issues = %v, want none", report.Issues)
+	}
+	if len(report.Parent) != 2 || len(report.Child) != 2 {
+		t.Errorf("Parent = %v, Child = %v", report.Parent, report.Child)
+	}
+
+	// Break the delegation: ns2 is not authoritative and serves a different serial,
+	// and a third registered nameserver does not resolve.
+	ns2.setAuthoritative(false)
+	report, err = c.CheckDelegation(context.Background(), "example.com",
+		[]string{"ns1.example.net", "ns2.example.net", "ns3.example.net"})
+	if err != nil {
+		t.Fatalf("CheckDelegation() error = %v", err)
+	}
+
+	lame := 0
+	for _, s := range report.Servers {
+		if s.Lame {
+			lame++
+		}
+	}
+	if lame != 2 {
+		t.Errorf("lame servers = %d, want 2: %+v", lame, report.Servers)
+	}
+
+	var parentDiff bool
+	for _, issue := range report.Issues {
+		if strings.Contains(issue, "parent NS set differs") && strings.Contains(issue, "ns3.example.net") {
+			parentDiff = true
+		}
+	}
+	if !parentDiff {
+		t.Errorf("expected parent NS mismatch issue, got %v", report.Issues)
+	}
+}
+
+func TestCheckDelegation_SerialMismatch(t *testing.T) {
+	ns1 := newTestServer(t)
+	ns1.add("example.com", 3600, soa(1))
+	ns2 := newTestServer(t)
+	ns2.add("example.com", 3600, soa(2))
+
+	c := NewClient()
+	c.ParentServers = func(context.Context, string) ([]Server, error) {
+		return nil, errors.New("offline")
+	}
+
+	report, err := c.CheckDelegation(context.Background(), "example.com", []string{ns1.Addr(), ns2.Addr()})
+	if err != nil {
+		t.Fatalf("CheckDelegation() error = %v", err)
+	}
+
+	var mismatch bool
+	for _, issue := range report.Issues {
+		if strings.HasPrefix(issue, "SOA serial mismatch") {
+			mismatch = true
+		}
+	}
+	if !mismatch {
+		t.Errorf("expected SOA serial mismatch, got %v", report.Issues)
+	}
+}
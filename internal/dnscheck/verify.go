@@ -0,0 +1,241 @@
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+)
+
+// Finding kinds reported by VerifyZone.
+const (
+	FindingMissing = "missing" // in the zone, not served
+	FindingExtra   = "extra"   // served, not in the zone
+	FindingStale   = "stale"   // served with a different TTL
+	FindingError   = "error"   // server failed to answer
+)
+
+// Finding is a discrepancy between zone contents and a nameserver's answers.
+type Finding struct {
+	Server   string `json:"server"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// VerifyReport summarises a zone verification.
+type VerifyReport struct {
+	Zone     string    `json:"zone"`
+	Servers  []Server  `json:"servers"`
+	Checked  int       `json:"checked"`
+	Skipped  []string  `json:"skipped,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+// rrset is the expected records for one owner name and type.
+type rrset struct {
+	name   string
+	typ    string
+	qtype  dnsmessage.Type
+	values map[string]uint32 // normalised value → TTL
+}
+
+// VerifyZone queries each server for every name/type in records and
+// compares the answers with the expected zone contents.
+func (c *Client) VerifyZone(ctx context.Context, zone string, records []api.DNSRecord, servers []Server) (*VerifyReport, error) {
+	sets, skipped := groupRecords(zone, records)
+
+	report := &VerifyReport{
+		Zone:     strings.TrimSuffix(Fqdn(zone), "."),
+		Servers:  servers,
+		Skipped:  skipped,
+		Findings: []Finding{},
+	}
+
+	for _, srv := range servers {
+		for _, set := range sets {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			resp, err := c.Exchange(ctx, srv.Addr, set.name, set.qtype)
+			if err != nil {
+				report.Findings = append(report.Findings, Finding{
+					Server: srv.Name, Name: set.name, Type: set.typ, Kind: FindingError, Actual: err.Error(),
+				})
+				continue
+			}
+			report.Checked++
+
+			if resp.RCode != dnsmessage.RCodeSuccess && resp.RCode != dnsmessage.RCodeNameError {
+				report.Findings = append(report.Findings, Finding{
+					Server: srv.Name, Name: set.name, Type: set.typ, Kind: FindingError, Actual: resp.RCode.String(),
+				})
+				continue
+			}
+
+			report.Findings = append(report.Findings, compareRRSet(srv.Name, set, Answers(resp, set.name, set.qtype))...)
+		}
+	}
+
+	return report, nil
+}
+
+// compareRRSet reports missing, extra and stale records for one server answer.
+func compareRRSet(server string, set *rrset, answers []RR) []Finding {
+	var findings []Finding
+	served := make(map[string]uint32, len(answers))
+	for _, rr := range answers {
+		served[rr.Value] = rr.TTL
+	}
+
+	for _, value := range sortedKeys(set.values) {
+		ttl := set.values[value]
+		got, ok := served[value]
+		switch {
+		case !ok:
+			findings = append(findings, Finding{
+				Server: server, Name: set.name, Type: set.typ, Kind: FindingMissing, Expected: value,
+			})
+		case ttl > 0 && got != ttl:
+			findings = append(findings, Finding{
+				Server: server, Name: set.name, Type: set.typ, Kind: FindingStale,
+				Expected: fmt.Sprintf("%s (ttl %d)", value, ttl),
+				Actual:   fmt.Sprintf("%s (ttl %d)", value, got),
+			})
+		}
+	}
+
+	for _, value := range sortedKeys(served) {
+		if _, ok := set.values[value]; !ok {
+			findings = append(findings, Finding{
+				Server: server, Name: set.name, Type: set.typ, Kind: FindingExtra, Actual: value,
+			})
+		}
+	}
+
+	return findings
+}
+
+// groupRecords groups zone records into rrsets keyed by owner name and type.
+// Record types that cannot be compared are returned as skipped.
+func groupRecords(zone string, records []api.DNSRecord) ([]*rrset, []string) {
+	byKey := make(map[string]*rrset)
+	var keys []string
+	skippedSet := make(map[string]bool)
+
+	for _, r := range records {
+		typ := strings.ToUpper(r.Type)
+		qtype, ok := queryTypes[typ]
+		if !ok || typ == "SOA" {
+			skippedSet[typ] = true
+			continue
+		}
+
+		name := OwnerName(zone, r.Name)
+		key := name + " " + typ
+		set, ok := byKey[key]
+		if !ok {
+			set = &rrset{name: name, typ: typ, qtype: qtype, values: make(map[string]uint32)}
+			byKey[key] = set
+			keys = append(keys, key)
+		}
+		set.values[RecordValue(r)] = uint32(max(r.TTL, 0)) //nolint:gosec // G115: TTL clamped to non-negative
+	}
+
+	sort.Strings(keys)
+	sets := make([]*rrset, 0, len(keys))
+	for _, k := range keys {
+		sets = append(sets, byKey[k])
+	}
+
+	skipped := make([]string, 0, len(skippedSet))
+	for typ := range skippedSet {
+		skipped = append(skipped, typ)
+	}
+	sort.Strings(skipped)
+
+	return sets, skipped
+}
+
+// OwnerName converts a zone record name ("@", "www", or absolute) to an FQDN.
+func OwnerName(zone, name string) string {
+	apex := Fqdn(zone)
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case name == "" || name == "@":
+		return apex
+	case strings.HasSuffix(name, "."):
+		return name
+	case name == strings.TrimSuffix(apex, ".") || strings.HasSuffix(name, "."+strings.TrimSuffix(apex, ".")):
+		return name + "."
+	default:
+		return name + "." + apex
+	}
+}
+
+// RecordValue normalises zone record content to the form produced by toRR.
+func RecordValue(r api.DNSRecord) string {
+	content := strings.TrimSpace(r.Content)
+	switch strings.ToUpper(r.Type) {
+	case "A", "AAAA":
+		if ip := net.ParseIP(content); ip != nil {
+			return ip.String()
+		}
+		return content
+	case "CNAME", "NS", "PTR":
+		return Fqdn(content)
+	case "MX":
+		return fmt.Sprintf("%d %s", r.Prio, Fqdn(content))
+	case "SRV":
+		// Content is "weight port target"; priority is stored separately.
+		fields := strings.Fields(content)
+		if len(fields) == 3 {
+			return fmt.Sprintf("%d %s %s %s", r.Prio, fields[0], fields[1], Fqdn(fields[2]))
+		}
+		return content
+	case "TXT":
+		return unquoteTXT(content)
+	default:
+		return content
+	}
+}
+
+// unquoteTXT joins "quoted" "strings" into a single value.
+func unquoteTXT(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	var sb strings.Builder
+	inQuote, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
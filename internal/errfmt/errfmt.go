@@ -3,7 +3,9 @@ package errfmt
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/dedene/realtime-register-cli/internal/api"
 	"github.com/dedene/realtime-register-cli/internal/auth"
@@ -45,17 +47,95 @@ func Format(err error) string {
 	case errors.As(err, &rateLimitErr):
 		sb.WriteString("Error: Rate limited by API.\n\n")
 		fmt.Fprintf(&sb, "Retry after: %d seconds\n", int(rateLimitErr.RetryAfter.Seconds()))
+		writeAttempts(&sb, rateLimitErr.Attempts)
 		return sb.String()
 
 	case errors.As(err, &apiErr):
-		fmt.Fprintf(&sb, "Error: API error (%d)\n\n", apiErr.StatusCode)
+		fmt.Fprintf(&sb, "Error: %s (%d)\n\n", apiErrorTitle(apiErr.StatusCode), apiErr.StatusCode)
 		fmt.Fprintf(&sb, "Message: %s\n", apiErr.Message)
+		if apiErr.Type != "" {
+			fmt.Fprintf(&sb, "Type: %s\n", apiErr.Type)
+		}
 		if apiErr.Details != "" {
 			fmt.Fprintf(&sb, "Details: %s\n", apiErr.Details)
 		}
+		if len(apiErr.Violations) > 0 {
+			sb.WriteString("\nProblems:\n")
+			for _, v := range apiErr.Violations {
+				fmt.Fprintf(&sb, "  - %s: %s\n", v.Field, v.Message)
+			}
+		}
+		if hints := apiErrorHints(apiErr); len(hints) > 0 {
+			sb.WriteString("\n")
+			for _, h := range hints {
+				fmt.Fprintf(&sb, "Hint: %s\n", h)
+			}
+		}
+		if apiErr.RequestID != "" {
+			fmt.Fprintf(&sb, "\nRequest ID: %s\n", apiErr.RequestID)
+		}
+		writeAttempts(&sb, apiErr.Attempts)
 		return sb.String()
 
 	default:
 		return fmt.Sprintf("Error: %s\n", err.Error())
 	}
 }
+
+// writeAttempts notes how often a request was retried before failing.
+func writeAttempts(sb *strings.Builder, attempts int) {
+	if attempts > 1 {
+		fmt.Fprintf(sb, "Attempts: %d\n", attempts)
+	}
+}
+
+func apiErrorTitle(status int) string {
+	switch status {
+	case 400:
+		return "Invalid request"
+	case 409:
+		return "Conflict"
+	case 422:
+		return "Request refused"
+	default:
+		return "API error"
+	}
+}
+
+// fieldHints suggest a fix for violations on fields with a path segment
+// named field, e.g. "ns" matches "ns[1]".
+var fieldHints = []struct {
+	field string
+	hint  string
+}{
+	{"properties", "Check the registry's contact properties with `rr tld properties <tld>` and set them with `rr contact set-properties`."},
+	{"period", "Check the allowed registration periods with `rr tld get <tld>`."},
+	{"ns", "Check the nameservers with `rr domain check-ns <domain> --ns <server>`."},
+	{"voice", "Phone numbers use the +CC.NUMBER format, e.g. +31.201234567."},
+	{"country", "Countries are two-letter ISO codes, e.g. NL or US."},
+}
+
+// apiErrorHints suggests fixes for an API error.
+func apiErrorHints(e *api.APIError) []string {
+	var hints []string
+	seen := make(map[string]bool)
+	for _, v := range e.Violations {
+		segments := strings.FieldsFunc(strings.ToLower(v.Field), func(r rune) bool { return !unicode.IsLetter(r) })
+		for _, fh := range fieldHints {
+			if slices.Contains(segments, fh.field) && !seen[fh.hint] {
+				seen[fh.hint] = true
+				hints = append(hints, fh.hint)
+			}
+		}
+	}
+
+	switch {
+	case e.StatusCode == 409:
+		hints = append(hints, "The object already exists or another process is changing it; check it with the matching `get` command or `rr process list`.")
+	case e.StatusCode == 422:
+		hints = append(hints, "The registry refused the request; `rr domain preflight` checks registrations before they are submitted.")
+	case len(e.Violations) > 0 && len(hints) == 0:
+		hints = append(hints, "Correct the fields listed above and try again.")
+	}
+	return hints
+}
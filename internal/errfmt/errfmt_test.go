@@ -0,0 +1,198 @@
+package errfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/auth"
+)
+
+const validationBody = `{
+	"type": "ValidationError",
+	"message": "Validation failed",
+	"violations": [
+		{"field": "ns[1]", "message": "does not resolve"},
+		{"field": "registrant.properties.language", "message": "is required"}
+	],
+	"requestId": "req-1"
+}`
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "no API key",
+			err:  fmt.Errorf("not authenticated: %w", auth.ErrNoAPIKey),
+			want: []string{"Error: Not authenticated.", "rr auth login", "RR_API_KEY"},
+		},
+		{
+			name: "auth",
+			err:  api.NewAPIError(401, []byte(`{"message":"Invalid API key"}`)),
+			want: []string{"Error: Authentication failed.", "Details: Invalid API key", "rr auth status"},
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("get domain: %w", api.NewAPIError(404, []byte(`{"message":"Domain not found"}`))),
+			want: []string{"Error: Domain not found"},
+		},
+		{
+			name: "rate limit",
+			err:  api.NewAPIError(429, []byte(`{"message":"slow down","retry_after":30}`)),
+			want: []string{"Error: Rate limited by API.", "Retry after: 30 seconds"},
+		},
+		{
+			name: "validation",
+			err:  api.NewAPIError(400, []byte(validationBody)),
+			want: []string{
+				"Error: Invalid request (400)",
+				"Message: Validation failed",
+				"Type: ValidationError",
+				"Problems:\n  - ns[1]: does not resolve\n  - registrant.properties.language: is required",
+				"Hint: Check the nameservers with `rr domain check-ns",
+				"Hint: Check the registry's contact properties",
+				"Request ID: req-1",
+			},
+		},
+		{
+			name: "validation without known fields",
+			err:  api.NewAPIError(400, []byte(`{"message":"bad","violations":[{"field":"email","message":"is invalid"}]}`)),
+			want: []string{"  - email: is invalid", "Hint: Correct the fields listed above and try again."},
+		},
+		{
+			name: "conflict",
+			err:  api.NewAPIError(409, []byte(`{"type":"ObjectExists","message":"Domain exists"}`)),
+			want: []string{"Error: Conflict (409)", "Type: ObjectExists", "Hint: The object already exists"},
+		},
+		{
+			name: "unprocessable",
+			err:  api.NewAPIError(422, []byte(`{"error":{"message":"Registry refused"}}`)),
+			want: []string{"Error: Request refused (422)", "Message: Registry refused", "rr domain preflight"},
+		},
+		{
+			name: "server error",
+			err:  api.NewAPIError(500, []byte(`{"message":"boom","detail":"try later"}`)),
+			want: []string{"Error: API error (500)", "Details: try later"},
+		},
+		{
+			name: "other",
+			err:  errors.New("something broke"),
+			want: []string{"Error: something broke\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Format(tt.err)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("Format() missing %q in:\n%s", w, got)
+				}
+			}
+		})
+	}
+
+	if got := Format(nil); got != "" {
+		t.Errorf("Format(nil) = %q, want empty", got)
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind string
+		code int
+		want jsonError
+	}{
+		{
+			name: "no API key",
+			err:  fmt.Errorf("not authenticated: %w", auth.ErrNoAPIKey),
+			kind: "auth", code: 3,
+			want: jsonError{Hints: []string{"Run `rr auth login` or set RR_API_KEY."}},
+		},
+		{
+			name: "auth",
+			err:  api.NewAPIError(403, []byte(`{"message":"Forbidden"}`)),
+			kind: "auth", code: 3,
+			want: jsonError{Status: 403},
+		},
+		{
+			name: "not found",
+			err:  api.NewAPIError(404, []byte(`{"message":"Domain not found","requestId":"req-2"}`)),
+			kind: "not_found", code: 6,
+			want: jsonError{Status: 404, RequestID: "req-2"},
+		},
+		{
+			name: "rate limit",
+			err:  api.NewAPIError(429, []byte(`{"retry_after":30}`)),
+			kind: "rate_limit", code: 5,
+			want: jsonError{Status: 429, RetryAfter: 30},
+		},
+		{
+			name: "validation",
+			err:  api.NewAPIError(400, []byte(validationBody)),
+			kind: "validation", code: 7,
+			want: jsonError{
+				Status:    400,
+				Type:      "ValidationError",
+				RequestID: "req-1",
+				Violations: []jsonViolation{
+					{Field: "ns[1]", Message: "does not resolve"},
+					{Field: "registrant.properties.language", Message: "is required"},
+				},
+				Hints: []string{
+					"Check the nameservers with `rr domain check-ns <domain> --ns <server>`.",
+					"Check the registry's contact properties with `rr tld properties <tld>` and set them with `rr contact set-properties`.",
+				},
+			},
+		},
+		{
+			name: "conflict",
+			err:  api.NewAPIError(409, []byte(`{"type":"ObjectExists","message":"exists"}`)),
+			kind: "conflict", code: 8,
+			want: jsonError{Status: 409, Type: "ObjectExists", Hints: []string{
+				"The object already exists or another process is changing it; check it with the matching `get` command or `rr process list`.",
+			}},
+		},
+		{
+			name: "other",
+			err:  errors.New("boom"),
+			kind: "error", code: 1,
+			want: jsonError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := FormatJSON(tt.err, tt.code, tt.kind)
+			if !strings.HasSuffix(out, "}\n") {
+				t.Errorf("FormatJSON() = %q, want one JSON line", out)
+			}
+			var got struct {
+				Error jsonError `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("FormatJSON() is not JSON: %v\n%s", err, out)
+			}
+
+			want := tt.want
+			want.Kind, want.ExitCode, want.Message = tt.kind, tt.code, tt.err.Error()
+			gotJSON, _ := json.Marshal(got.Error)
+			wantJSON, _ := json.Marshal(want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("FormatJSON()\n got %s\nwant %s", gotJSON, wantJSON)
+			}
+		})
+	}
+
+	if got := FormatJSON(nil, 0, ""); got != "" {
+		t.Errorf("FormatJSON(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,67 @@
+package errfmt
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/auth"
+)
+
+// jsonError is the machine-readable form of an error, written to stderr
+// when --json is set.
+type jsonError struct {
+	Kind       string          `json:"kind"`
+	ExitCode   int             `json:"exitCode"`
+	Message    string          `json:"message"`
+	Status     int             `json:"status,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Details    string          `json:"details,omitempty"`
+	Violations []jsonViolation `json:"violations,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+	Attempts   int             `json:"attempts,omitempty"`
+	RetryAfter int             `json:"retryAfter,omitempty"` // seconds
+	Hints      []string        `json:"hints,omitempty"`
+}
+
+type jsonViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FormatJSON returns err as a JSON object of the form {"error": {...}},
+// followed by a newline. kind and exitCode describe how the process exits.
+func FormatJSON(err error, exitCode int, kind string) string {
+	if err == nil {
+		return ""
+	}
+
+	je := jsonError{Kind: kind, ExitCode: exitCode, Message: err.Error()}
+
+	var rateLimitErr *api.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		je.RetryAfter = int(rateLimitErr.RetryAfter.Seconds())
+	}
+
+	var apiErr *api.APIError
+	switch {
+	case errors.Is(err, auth.ErrNoAPIKey):
+		je.Hints = []string{"Run `rr auth login` or set RR_API_KEY."}
+	case errors.As(err, &apiErr):
+		je.Status = apiErr.StatusCode
+		je.Type = apiErr.Type
+		je.Details = apiErr.Details
+		je.RequestID = apiErr.RequestID
+		je.Attempts = apiErr.Attempts
+		for _, v := range apiErr.Violations {
+			je.Violations = append(je.Violations, jsonViolation{Field: v.Field, Message: v.Message})
+		}
+		je.Hints = apiErrorHints(apiErr)
+	}
+
+	raw, mErr := json.Marshal(map[string]jsonError{"error": je})
+	if mErr != nil {
+		return ""
+	}
+	return string(raw) + "\n"
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -124,3 +125,15 @@ func WriteTSV(w io.Writer, _ []string, rows [][]string) error {
 	}
 	return nil
 }
+
+// WriteCSV writes a header row followed by rows as RFC 4180 CSV
+func WriteCSV(w io.Writer, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
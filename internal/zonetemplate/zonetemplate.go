@@ -0,0 +1,239 @@
+package zonetemplate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dedene/realtime-register-cli/internal/api"
+	"github.com/dedene/realtime-register-cli/internal/config"
+)
+
+// Template sources.
+const (
+	SourceBuiltin = "builtin"
+	SourceUser    = "user"
+)
+
+const defaultTTL = 3600
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// varPattern matches {{ name }} placeholders.
+var varPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// Template is a named set of records with variables.
+type Template struct {
+	Name        string     `yaml:"-" json:"name"`
+	Source      string     `yaml:"-" json:"source"`
+	Description string     `yaml:"description" json:"description"`
+	Variables   []Variable `yaml:"variables" json:"variables,omitempty"`
+	Records     []Record   `yaml:"records" json:"records"`
+}
+
+// Variable declares a placeholder used by template records.
+type Variable struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Default     string `yaml:"default" json:"default,omitempty"`
+	Required    bool   `yaml:"required" json:"required,omitempty"`
+}
+
+// Record is a record definition in a template.
+// When names a variable that must be set for the record to be included.
+type Record struct {
+	Name     string `yaml:"name" json:"name"`
+	Type     string `yaml:"type" json:"type"`
+	Content  string `yaml:"content" json:"content"`
+	TTL      int    `yaml:"ttl" json:"ttl,omitempty"`
+	Priority int    `yaml:"priority" json:"priority,omitempty"`
+	When     string `yaml:"when" json:"when,omitempty"`
+}
+
+// List returns all available templates sorted by name.
+// User templates override built-in templates with the same name.
+func List() ([]*Template, error) {
+	byName := make(map[string]*Template)
+
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, fmt.Errorf("read builtin templates: %w", err)
+	}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".yaml")
+		t, err := loadBuiltin(name)
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = t
+	}
+
+	dir, err := config.TemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+	userEntries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read template dir: %w", err)
+	}
+	for _, e := range userEntries {
+		name, ok := templateName(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		t, err := loadFile(filepath.Join(dir, e.Name()), name)
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = t
+	}
+
+	templates := make([]*Template, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Load returns a template by name, preferring the user template directory.
+// Names are plain file names; paths are rejected so a template cannot be
+// read from outside the template directory.
+func Load(name string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return nil, fmt.Errorf("invalid template name %q; run: rr zone templates", name)
+	}
+
+	dir, err := config.TemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		p := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(p); err == nil {
+			return loadFile(p, name)
+		}
+	}
+
+	t, err := loadBuiltin(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unknown template %q; run: rr zone templates", name)
+	}
+	return t, err
+}
+
+// Render substitutes variables and returns the resulting records.
+// The domain variable is always set to the zone name.
+func (t *Template) Render(domain string, vars map[string]string) ([]api.DNSRecord, error) {
+	values := make(map[string]string, len(vars)+1)
+	for k, v := range vars {
+		values[k] = v
+	}
+	values["domain"] = domain
+
+	for _, v := range t.Variables {
+		if val, ok := vars[v.Name]; ok && val != "" {
+			values[v.Name] = val
+			continue
+		}
+		if v.Default != "" {
+			def, err := substitute(v.Default, values)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: variable %s: %w", t.Name, v.Name, err)
+			}
+			values[v.Name] = def
+			continue
+		}
+		if v.Required {
+			return nil, fmt.Errorf("template %s: missing required variable %q (use --var %s=...)", t.Name, v.Name, v.Name)
+		}
+	}
+
+	records := make([]api.DNSRecord, 0, len(t.Records))
+	for _, r := range t.Records {
+		if r.When != "" && values[r.When] == "" {
+			continue
+		}
+		content, err := substitute(r.Content, values)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: record %s %s: %w", t.Name, r.Type, r.Name, err)
+		}
+		name, err := substitute(r.Name, values)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: record %s %s: %w", t.Name, r.Type, r.Name, err)
+		}
+		ttl := r.TTL
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+		records = append(records, api.DNSRecord{
+			Name:    name,
+			Type:    strings.ToUpper(r.Type),
+			Content: content,
+			TTL:     ttl,
+			Prio:    r.Priority,
+		})
+	}
+
+	return records, nil
+}
+
+// substitute replaces {{ name }} placeholders, failing on unknown variables.
+func substitute(s string, values map[string]string) (string, error) {
+	var missing string
+	out := varPattern.ReplaceAllStringFunc(s, func(m string) string {
+		name := varPattern.FindStringSubmatch(m)[1]
+		val, ok := values[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return val
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %q", missing)
+	}
+	return out, nil
+}
+
+func loadBuiltin(name string) (*Template, error) {
+	data, err := builtinFS.ReadFile("builtin/" + name + ".yaml")
+	if err != nil {
+		return nil, err
+	}
+	return parse(data, name, SourceBuiltin)
+}
+
+func loadFile(path, name string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	return parse(data, name, SourceUser)
+}
+
+func parse(data []byte, name, source string) (*Template, error) {
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+	t.Name = name
+	t.Source = source
+	return &t, nil
+}
+
+func templateName(file string) (string, bool) {
+	for _, ext := range []string{".yaml", ".yml"} {
+		if strings.HasSuffix(file, ext) {
+			return strings.TrimSuffix(file, ext), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,89 @@
+package zonetemplate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTemplatesRender(t *testing.T) {
+	vars := map[string]string{
+		"tenant": "example-com",
+		"ipv4":   "192.0.2.10",
+	}
+
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := loadBuiltin(name)
+			if err != nil {
+				t.Fatalf("loadBuiltin(%q) error = %v", name, err)
+			}
+			records, err := tmpl.Render("example.com", vars)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if len(records) == 0 {
+				t.Error("Render() returned no records")
+			}
+			for _, r := range records {
+				if strings.Contains(r.Content, "{{") {
+					t.Errorf("unsubstituted placeholder in %s %s: %q", r.Type, r.Name, r.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tmpl := &Template{
+		Name: "test",
+		Variables: []Variable{
+			{Name: "ip", Required: true},
+			{Name: "ipv6"},
+			{Name: "rua", Default: "dmarc@{{ domain }}"},
+		},
+		Records: []Record{
+			{Name: "@", Type: "a", Content: "{{ ip }}"},
+			{Name: "@", Type: "AAAA", Content: "{{ipv6}}", When: "ipv6"},
+			{Name: "_dmarc", Type: "TXT", Content: "v=DMARC1; rua=mailto:{{ rua }}", TTL: 300},
+		},
+	}
+
+	records, err := tmpl.Render("example.com", map[string]string{"ip": "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Render() = %d records, want 2 (AAAA skipped)", len(records))
+	}
+	if records[0].Type != "A" || records[0].Content != "192.0.2.1" || records[0].TTL != defaultTTL {
+		t.Errorf("records[0] = %+v", records[0])
+	}
+	if want := "v=DMARC1; rua=mailto:dmarc@example.com"; records[1].Content != want {
+		t.Errorf("records[1].Content = %q, want %q", records[1].Content, want)
+	}
+	if records[1].TTL != 300 {
+		t.Errorf("records[1].TTL = %d, want 300", records[1].TTL)
+	}
+
+	if _, err := tmpl.Render("example.com", nil); err == nil {
+		t.Error("Render() without required variable: expected error")
+	}
+
+	tmpl.Records = append(tmpl.Records, Record{Name: "x", Type: "TXT", Content: "{{ unknown }}"})
+	if _, err := tmpl.Render("example.com", map[string]string{"ip": "192.0.2.1"}); err == nil {
+		t.Error("Render() with undefined variable: expected error")
+	}
+}
+
+func TestLoadRejectsPaths(t *testing.T) {
+	for _, name := range []string{"", "../secret", "a/b", `a\b`, "..", "x..y"} {
+		if _, err := Load(name); err == nil || !strings.Contains(err.Error(), "invalid template name") {
+			t.Errorf("Load(%q) error = %v, want invalid template name", name, err)
+		}
+	}
+}